@@ -1,6 +1,9 @@
 package abstractions
 
 import (
+	"bytes"
+	"iter"
+	"strings"
 	"testing"
 
 	"github.com/microsoft/kiota-abstractions-go/internal"
@@ -70,6 +73,47 @@ func TestItSerializesACollectionOfObjects(t *testing.T) {
 	serialization.DefaultSerializationWriterFactoryInstance.ContentTypeAssociatedFactories = make(map[string]serialization.SerializationWriterFactory)
 }
 
+func TestItDefendsStreamSerializationNilWriter(t *testing.T) {
+	err := serialization.SerializeTo(nil, jsonContentType, internal.NewPerson())
+	assert.NotNil(t, err)
+}
+
+func TestItSerializesObjectToWriter(t *testing.T) {
+	serializedValue := "{\"id\":\"123\"}"
+	metaFactory := func() serialization.SerializationWriterFactory {
+		return &internal.MockSerializerFactory{
+			SerializedValue: serializedValue,
+		}
+	}
+	RegisterDefaultSerializer(metaFactory)
+	person := internal.NewPerson()
+	id := "123"
+	person.SetId(&id)
+	var buffer bytes.Buffer
+	err := serialization.SerializeToJsonWriter(&buffer, person)
+	assert.Nil(t, err)
+	assert.Equal(t, serializedValue, buffer.String())
+	serialization.DefaultSerializationWriterFactoryInstance.ContentTypeAssociatedFactories = make(map[string]serialization.SerializationWriterFactory)
+}
+
+func TestItSerializesACollectionOfObjectsToWriter(t *testing.T) {
+	serializedValue := "[{\"id\":\"123\"}]"
+	metaFactory := func() serialization.SerializationWriterFactory {
+		return &internal.MockSerializerFactory{
+			SerializedValue: serializedValue,
+		}
+	}
+	RegisterDefaultSerializer(metaFactory)
+	person := internal.NewPerson()
+	id := "123"
+	person.SetId(&id)
+	var buffer bytes.Buffer
+	err := serialization.SerializeCollectionToJsonWriter(&buffer, []serialization.Parsable{person})
+	assert.Nil(t, err)
+	assert.Equal(t, serializedValue, buffer.String())
+	serialization.DefaultSerializationWriterFactoryInstance.ContentTypeAssociatedFactories = make(map[string]serialization.SerializationWriterFactory)
+}
+
 func TestItDefendsDeserializationEmptyContentType(t *testing.T) {
 	result, err := serialization.Deserialize("", nil, nil)
 	assert.Nil(t, result)
@@ -141,3 +185,64 @@ func TestItDeserializesAnObjectCollection(t *testing.T) {
 	assert.Equal(t, id, *resultAsPerson.GetId())
 	serialization.DefaultParseNodeFactoryInstance.ContentTypeAssociatedFactories = make(map[string]serialization.ParseNodeFactory)
 }
+
+func TestItDefendsCollectionStreamDeserializationNilContent(t *testing.T) {
+	for result, err := range serialization.DeserializeCollectionFrom(jsonContentType, nil, internal.CreatePersonFromDiscriminatorValue) {
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestItDeserializesAnObjectCollectionFromReader(t *testing.T) {
+	first := internal.NewPerson()
+	firstId := "123"
+	first.SetId(&firstId)
+	second := internal.NewPerson()
+	secondId := "456"
+	second.SetId(&secondId)
+	metaFactory := func() serialization.ParseNodeFactory {
+		return &internal.MockParseNodeFactory{
+			SerializedValue: []serialization.Parsable{first, second},
+		}
+	}
+	RegisterDefaultDeserializer(metaFactory)
+
+	ids := make([]string, 0)
+	for result, err := range serialization.DeserializeCollectionFromJsonReader(strings.NewReader("[{\"id\": \"123\"},{\"id\": \"456\"}]"), internal.CreatePersonFromDiscriminatorValue) {
+		assert.Nil(t, err)
+		resultAsPerson, ok := result.(*internal.Person)
+		assert.True(t, ok)
+		ids = append(ids, *resultAsPerson.GetId())
+	}
+	assert.Equal(t, []string{firstId, secondId}, ids)
+	serialization.DefaultParseNodeFactoryInstance.ContentTypeAssociatedFactories = make(map[string]serialization.ParseNodeFactory)
+}
+
+type mockIteratorParseNode struct {
+	internal.MockParseNode
+	items []serialization.Parsable
+}
+
+func (m *mockIteratorParseNode) IterateCollectionOfObjectValues(ctor serialization.ParsableFactory) iter.Seq2[serialization.Parsable, error] {
+	return func(yield func(serialization.Parsable, error) bool) {
+		for _, item := range m.items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestItIteratesOverACollectionWithoutMaterialisingIt(t *testing.T) {
+	node := &mockIteratorParseNode{
+		items: []serialization.Parsable{internal.NewPerson(), internal.NewPerson()},
+	}
+	count := 0
+	for result, err := range serialization.IterateCollectionOfObjectValues(node, internal.CreatePersonFromDiscriminatorValue) {
+		assert.Nil(t, err)
+		assert.NotNil(t, result)
+		count++
+		break
+	}
+	assert.Equal(t, 1, count)
+}
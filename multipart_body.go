@@ -2,6 +2,8 @@ package abstractions
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"strings"
 
 	"github.com/google/uuid"
@@ -12,9 +14,23 @@ import (
 type MultipartBody interface {
 	serialization.Parsable
 	// AddOrReplacePart adds or replaces a part in the multipart body.
+	// The content can be a Parsable, a string, a byte array or an io.Reader which is streamed when the body is serialized.
+	// A replaced part keeps its position, file name and additional headers.
 	AddOrReplacePart(name string, contentType string, content any) error
+	// AddOrReplacePartWithFileName adds or replaces a part in the multipart body, the file name is added to the part's Content-Disposition header.
+	// A replaced part keeps its position and additional headers.
+	AddOrReplacePartWithFileName(name string, contentType string, fileName string, content any) error
 	// GetPartValue gets the value of a part in the multipart body.
 	GetPartValue(name string) (any, error)
+	// GetPartFileName gets the file name of a part in the multipart body, empty when the part has no file name.
+	GetPartFileName(name string) (string, error)
+	// SetPartHeader sets an additional header on a part of the multipart body, replacing any existing value for that header.
+	// Content-Type and Content-Disposition cannot be set this way.
+	SetPartHeader(name string, headerName string, headerValue string) error
+	// GetPartHeader gets the value of an additional header of a part in the multipart body, empty when the header is not set.
+	GetPartHeader(name string, headerName string) (string, error)
+	// GetPartNames returns the names of the parts in the order they were added.
+	GetPartNames() []string
 	// RemovePart removes a part from the multipart body.
 	RemovePart(name string) error
 	// SetRequestAdapter sets the request adapter to use for serialization.
@@ -23,21 +39,61 @@ type MultipartBody interface {
 	GetRequestAdapter() RequestAdapter
 	// GetBoundary returns the boundary used in the multipart body.
 	GetBoundary() string
+	// GetSubtype returns the multipart subtype of the body, form-data, mixed or related.
+	GetSubtype() string
+	// SetRootPart sets the part serialized first and referenced by the start parameter of a multipart/related body.
+	SetRootPart(name string) error
+	// GetRootPart gets the name of the root part, empty when no root part was set.
+	GetRootPart() string
+	// SetPartContentId sets the Content-ID header of a part so it can be referenced by other parts.
+	SetPartContentId(name string, contentId string) error
+	// GetPartContentIdReference returns the cid: URL referencing a part by its Content-ID, empty when the part has no Content-ID.
+	GetPartContentIdReference(name string) (string, error)
 }
+
+const (
+	// MultipartFormDataSubtype is the subtype of multipart/form-data bodies, each part is described by a form-data Content-Disposition.
+	MultipartFormDataSubtype = "form-data"
+	// MultipartMixedSubtype is the subtype of multipart/mixed bodies, parts are independent entities.
+	MultipartMixedSubtype = "mixed"
+	// MultipartRelatedSubtype is the subtype of multipart/related bodies, parts are aggregated around a root part and referenced by Content-ID.
+	MultipartRelatedSubtype = "related"
+)
+
 type multipartBody struct {
 	parts            map[string]multipartEntry
+	partNames        []string
 	originalNamesMap map[string]string
 	boundary         string
+	subtype          string
+	rootPart         string
 	requestAdapter   RequestAdapter
 }
 
+// NewMultipartBody creates a new multipart/form-data body.
 func NewMultipartBody() MultipartBody {
+	return NewMultipartBodyWithSubtype(MultipartFormDataSubtype)
+}
+
+// NewMultipartBodyWithSubtype creates a new multipart body with the given subtype, see MultipartFormDataSubtype, MultipartMixedSubtype and MultipartRelatedSubtype.
+func NewMultipartBodyWithSubtype(subtype string) MultipartBody {
+	subtype = strings.ToLower(strings.TrimSpace(subtype))
+	if subtype == "" {
+		subtype = MultipartFormDataSubtype
+	}
 	return &multipartBody{
 		parts:            make(map[string]multipartEntry),
 		originalNamesMap: make(map[string]string),
 		boundary:         strings.ReplaceAll(uuid.New().String(), "-", ""),
+		subtype:          subtype,
 	}
 }
+
+// CreateMultipartBodyFromDiscriminatorValue creates a new MultipartBody to be populated from a parse node.
+func CreateMultipartBodyFromDiscriminatorValue(parseNode serialization.ParseNode) (serialization.Parsable, error) {
+	return NewMultipartBody(), nil
+}
+
 func normalizePartName(original string) string {
 	return strings.ToLower(original)
 }
@@ -45,8 +101,41 @@ func stringReference(original string) *string {
 	return &original
 }
 
+const contentDispositionHeader = "Content-Disposition"
+const contentIdHeader = "Content-ID"
+
+var quotedStringEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"")
+
+// escapeQuotedString escapes a value so it can be used as a quoted-string header parameter,
+// values containing line breaks or NUL characters are rejected as they cannot be escaped.
+func escapeQuotedString(value string) (string, error) {
+	if err := validateHeaderText("quoted string", value); err != nil {
+		return "", err
+	}
+	return quotedStringEscaper.Replace(value), nil
+}
+
+// validateHeaderText returns an error when the text contains a character that would end the header it's written in.
+func validateHeaderText(description string, text string) error {
+	if strings.ContainsAny(text, "\r\n\x00") {
+		return errors.New(description + " cannot contain CR, LF or NUL characters")
+	}
+	return nil
+}
+
 // AddOrReplacePart adds or replaces a part in the multipart body.
+// A replaced part keeps its position, file name and additional headers.
 func (m *multipartBody) AddOrReplacePart(name string, contentType string, content any) error {
+	fileName := ""
+	if part, ok := m.parts[normalizePartName(name)]; ok {
+		fileName = part.FileName
+	}
+	return m.AddOrReplacePartWithFileName(name, contentType, fileName, content)
+}
+
+// AddOrReplacePartWithFileName adds or replaces a part in the multipart body, the file name is added to the part's Content-Disposition header.
+// A replaced part keeps its position and additional headers.
+func (m *multipartBody) AddOrReplacePartWithFileName(name string, contentType string, fileName string, content any) error {
 	if name == "" {
 		return errors.New("name cannot be empty")
 	}
@@ -56,10 +145,25 @@ func (m *multipartBody) AddOrReplacePart(name string, contentType string, conten
 	if content == nil {
 		return errors.New("content cannot be nil")
 	}
+	if err := validateHeaderText("name", name); err != nil {
+		return err
+	}
+	if err := validateHeaderText("contentType", contentType); err != nil {
+		return err
+	}
+	if err := validateHeaderText("fileName", fileName); err != nil {
+		return err
+	}
 	normalizedName := normalizePartName(name)
+	part, ok := m.parts[normalizedName]
+	if !ok {
+		m.partNames = append(m.partNames, normalizedName)
+	}
 	m.parts[normalizedName] = multipartEntry{
 		ContentType: contentType,
 		Content:     content,
+		FileName:    fileName,
+		Headers:     part.Headers,
 	}
 	m.originalNamesMap[normalizedName] = name
 
@@ -78,12 +182,106 @@ func (m *multipartBody) GetPartValue(name string) (any, error) {
 	return nil, nil
 }
 
+// GetPartFileName gets the file name of a part in the multipart body, empty when the part has no file name.
+func (m *multipartBody) GetPartFileName(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("name cannot be empty")
+	}
+	normalizedName := normalizePartName(name)
+	if part, ok := m.parts[normalizedName]; ok {
+		return part.FileName, nil
+	}
+	return "", nil
+}
+
+// SetPartHeader sets an additional header on a part of the multipart body, replacing any existing value for that header.
+// Content-Type and Content-Disposition cannot be set this way.
+func (m *multipartBody) SetPartHeader(name string, headerName string, headerValue string) error {
+	if name == "" {
+		return errors.New("name cannot be empty")
+	}
+	headerName = strings.TrimSpace(headerName)
+	if headerName == "" {
+		return errors.New("headerName cannot be empty")
+	}
+	if strings.EqualFold(headerName, contentTypeHeader) || strings.EqualFold(headerName, contentDispositionHeader) {
+		return errors.New(headerName + " is managed by the multipart body and cannot be set as a part header")
+	}
+	if err := validateHeaderText("headerName", headerName); err != nil {
+		return err
+	}
+	if strings.Contains(headerName, ":") {
+		return errors.New("headerName cannot contain a colon")
+	}
+	if err := validateHeaderText("headerValue", headerValue); err != nil {
+		return err
+	}
+	normalizedName := normalizePartName(name)
+	part, ok := m.parts[normalizedName]
+	if !ok {
+		return errors.New("part " + name + " does not exist")
+	}
+	headers := make([]multipartHeader, 0, len(part.Headers)+1)
+	replaced := false
+	for _, header := range part.Headers {
+		if strings.EqualFold(header.Name, headerName) {
+			if !replaced && headerValue != "" {
+				headers = append(headers, multipartHeader{Name: headerName, Value: headerValue})
+			}
+			replaced = true
+		} else {
+			headers = append(headers, header)
+		}
+	}
+	if !replaced && headerValue != "" {
+		headers = append(headers, multipartHeader{Name: headerName, Value: headerValue})
+	}
+	part.Headers = headers
+	m.parts[normalizedName] = part
+	return nil
+}
+
+// GetPartHeader gets the value of an additional header of a part in the multipart body, empty when the header is not set.
+func (m *multipartBody) GetPartHeader(name string, headerName string) (string, error) {
+	if name == "" {
+		return "", errors.New("name cannot be empty")
+	}
+	if part, ok := m.parts[normalizePartName(name)]; ok {
+		for _, header := range part.Headers {
+			if strings.EqualFold(header.Name, strings.TrimSpace(headerName)) {
+				return header.Value, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// GetPartNames returns the names of the parts in the order they were added.
+func (m *multipartBody) GetPartNames() []string {
+	names := make([]string, len(m.partNames))
+	for i, normalizedName := range m.partNames {
+		names[i] = m.originalNamesMap[normalizedName]
+	}
+	return names
+}
+
 // RemovePart removes a part from the multipart body.
 func (m *multipartBody) RemovePart(name string) error {
 	if name == "" {
 		return errors.New("name cannot be empty")
 	}
 	normalizedName := normalizePartName(name)
+	if _, ok := m.parts[normalizedName]; ok {
+		if m.rootPart == normalizedName {
+			m.rootPart = ""
+		}
+		for i, partName := range m.partNames {
+			if partName == normalizedName {
+				m.partNames = append(m.partNames[:i], m.partNames[i+1:]...)
+				break
+			}
+		}
+	}
 	delete(m.parts, normalizedName)
 	delete(m.originalNamesMap, normalizedName)
 	return nil
@@ -106,7 +304,8 @@ func (m *multipartBody) Serialize(writer serialization.SerializationWriter) erro
 	}
 
 	first := true
-	for partName, part := range m.parts {
+	for _, partName := range m.getSerializationOrder() {
+		part := m.parts[partName]
 		if first {
 			first = false
 		} else {
@@ -117,30 +316,49 @@ func (m *multipartBody) Serialize(writer serialization.SerializationWriter) erro
 		if err := writer.WriteStringValue("", stringReference("--"+m.boundary)); err != nil {
 			return err
 		}
-		if err := writer.WriteStringValue("Content-Type", stringReference(part.ContentType)); err != nil {
+		partContentType := part.ContentType
+		nestedBody, isNestedBody := part.Content.(MultipartBody)
+		if isNestedBody {
+			var err error
+			if partContentType, err = multipartContentType(partContentType, nestedBody); err != nil {
+				return err
+			}
+		}
+		if err := writer.WriteStringValue(contentTypeHeader, stringReference(partContentType)); err != nil {
 			return err
 		}
-		partOriginalName := m.originalNamesMap[partName]
-		if err := writer.WriteStringValue("Content-Disposition", stringReference("form-data; name=\""+partOriginalName+"\"")); err != nil {
+		contentDisposition, err := m.getContentDisposition(partName, part)
+		if err != nil {
 			return err
 		}
+		if contentDisposition != "" {
+			if err := writer.WriteStringValue(contentDispositionHeader, stringReference(contentDisposition)); err != nil {
+				return err
+			}
+		}
+		for _, header := range part.Headers {
+			if err := writer.WriteStringValue(header.Name, stringReference(header.Value)); err != nil {
+				return err
+			}
+		}
 		if err := writer.WriteStringValue("", stringReference("")); err != nil {
 			return err
 		}
-		if parsable, ok := part.Content.(serialization.Parsable); ok {
-			partWriter, error := serializationWriterFactory.GetSerializationWriter(part.ContentType)
-			defer partWriter.Close()
-			if error != nil {
+		if isNestedBody {
+			nestedBody.SetRequestAdapter(m.requestAdapter)
+			if error := nestedBody.Serialize(writer); error != nil {
 				return error
 			}
-			if error = partWriter.WriteObjectValue("", parsable); error != nil {
+		} else if parsable, ok := part.Content.(serialization.Parsable); ok {
+			partWriter, error := serialization.NewStreamSerializationWriter(serializationWriterFactory, part.ContentType, serializationWriterStream{writer})
+			if error != nil {
 				return error
 			}
-			partContent, error := partWriter.GetSerializedContent()
-			if error != nil {
+			if error = partWriter.WriteObjectValue("", parsable); error != nil {
+				partWriter.Close()
 				return error
 			}
-			if error = writer.WriteByteArrayValue("", partContent); error != nil {
+			if error = partWriter.Close(); error != nil {
 				return error
 			}
 		} else if str, ok := part.Content.(string); ok {
@@ -151,6 +369,10 @@ func (m *multipartBody) Serialize(writer serialization.SerializationWriter) erro
 			if error := writer.WriteByteArrayValue("", byteArray); error != nil {
 				return error
 			}
+		} else if reader, ok := part.Content.(io.Reader); ok {
+			if _, error := io.Copy(serializationWriterStream{writer}, reader); error != nil {
+				return error
+			}
 		} else {
 			return errors.New("unsupported part type")
 		}
@@ -165,9 +387,163 @@ func (m *multipartBody) Serialize(writer serialization.SerializationWriter) erro
 	return nil
 }
 
+// getSerializationOrder returns the normalized part names in insertion order, with the root part first.
+func (m *multipartBody) getSerializationOrder() []string {
+	if m.rootPart == "" {
+		return m.partNames
+	}
+	order := make([]string, 0, len(m.partNames))
+	order = append(order, m.rootPart)
+	for _, partName := range m.partNames {
+		if partName != m.rootPart {
+			order = append(order, partName)
+		}
+	}
+	return order
+}
+
+// getContentDisposition returns the Content-Disposition header of a part, empty when the part doesn't need one.
+func (m *multipartBody) getContentDisposition(partName string, part multipartEntry) (string, error) {
+	fileNameParameter := ""
+	if part.FileName != "" {
+		fileName, err := escapeQuotedString(part.FileName)
+		if err != nil {
+			return "", err
+		}
+		fileNameParameter = "; filename=\"" + fileName + "\""
+	}
+	if m.subtype == MultipartFormDataSubtype {
+		name, err := escapeQuotedString(m.originalNamesMap[partName])
+		if err != nil {
+			return "", err
+		}
+		return "form-data; name=\"" + name + "\"" + fileNameParameter, nil
+	} else if fileNameParameter != "" {
+		return "attachment" + fileNameParameter, nil
+	}
+	return "", nil
+}
+
+// multipartReadersRewinder returns a function seeking the io.Reader parts of the body and of its nested bodies back to their current offset,
+// so the body can be serialized again. The function returns an error when one of the readers is not an io.Seeker.
+func multipartReadersRewinder(body MultipartBody) (func() error, error) {
+	rewinders := make([]func() error, 0)
+	for _, name := range body.GetPartNames() {
+		value, err := body.GetPartValue(name)
+		if err != nil {
+			return nil, err
+		}
+		switch content := value.(type) {
+		case MultipartBody:
+			rewinder, err := multipartReadersRewinder(content)
+			if err != nil {
+				return nil, err
+			}
+			rewinders = append(rewinders, rewinder)
+		case serialization.Parsable, string, []byte:
+		case io.Reader:
+			seeker, ok := content.(io.Seeker)
+			if !ok {
+				rewinders = append(rewinders, func() error {
+					return fmt.Errorf("the reader of the part %s cannot be rewound", name)
+				})
+				continue
+			}
+			offset, err := seeker.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return nil, err
+			}
+			rewinders = append(rewinders, func() error {
+				_, err := seeker.Seek(offset, io.SeekStart)
+				return err
+			})
+		}
+	}
+	return func() error {
+		for _, rewinder := range rewinders {
+			if err := rewinder(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// multipartContentType returns the content type of a multipart body with its boundary,
+// and for multipart/related bodies with a root part the type and start parameters.
+func multipartContentType(contentType string, body MultipartBody) (string, error) {
+	if err := validateHeaderText("boundary", body.GetBoundary()); err != nil {
+		return "", err
+	}
+	contentType += "; boundary=" + body.GetBoundary()
+	if body.GetSubtype() != MultipartRelatedSubtype || body.GetRootPart() == "" {
+		return contentType, nil
+	}
+	rootPart := body.GetRootPart()
+	if concrete, ok := body.(*multipartBody); ok {
+		if part, ok := concrete.parts[rootPart]; ok {
+			rootType, err := escapeQuotedString(strings.Split(part.ContentType, ";")[0])
+			if err != nil {
+				return "", err
+			}
+			contentType += "; type=\"" + rootType + "\""
+		}
+	}
+	if contentId, err := body.GetPartHeader(rootPart, contentIdHeader); err == nil && contentId != "" {
+		start, err := escapeQuotedString(contentId)
+		if err != nil {
+			return "", err
+		}
+		contentType += "; start=\"" + start + "\""
+	}
+	return contentType, nil
+}
+
+// GetSubtype returns the multipart subtype of the body, form-data, mixed or related.
+func (m *multipartBody) GetSubtype() string {
+	return m.subtype
+}
+
+// SetRootPart sets the part serialized first and referenced by the start parameter of a multipart/related body.
+func (m *multipartBody) SetRootPart(name string) error {
+	if name == "" {
+		return errors.New("name cannot be empty")
+	}
+	normalizedName := normalizePartName(name)
+	if _, ok := m.parts[normalizedName]; !ok {
+		return errors.New("part " + name + " does not exist")
+	}
+	m.rootPart = normalizedName
+	return nil
+}
+
+// GetRootPart gets the name of the root part, empty when no root part was set.
+func (m *multipartBody) GetRootPart() string {
+	return m.originalNamesMap[m.rootPart]
+}
+
+// SetPartContentId sets the Content-ID header of a part so it can be referenced by other parts.
+func (m *multipartBody) SetPartContentId(name string, contentId string) error {
+	contentId = strings.Trim(strings.TrimSpace(contentId), "<>")
+	if contentId == "" {
+		return errors.New("contentId cannot be empty")
+	}
+	return m.SetPartHeader(name, contentIdHeader, "<"+contentId+">")
+}
+
+// GetPartContentIdReference returns the cid: URL referencing a part by its Content-ID, empty when the part has no Content-ID.
+func (m *multipartBody) GetPartContentIdReference(name string) (string, error) {
+	contentId, err := m.GetPartHeader(name, contentIdHeader)
+	if err != nil || contentId == "" {
+		return "", err
+	}
+	return "cid:" + strings.Trim(contentId, "<>"), nil
+}
+
 // GetFieldDeserializers returns the deserialization information for this object.
+// Parts are populated by the multipart parse node, the body doesn't define any field.
 func (m *multipartBody) GetFieldDeserializers() map[string]func(serialization.ParseNode) error {
-	panic("not implemented")
+	return make(map[string]func(serialization.ParseNode) error)
 }
 
 // GetRequestAdapter gets the request adapter to use for serialization.
@@ -188,4 +564,24 @@ func (m *multipartBody) GetBoundary() string {
 type multipartEntry struct {
 	ContentType string
 	Content     any
+	FileName    string
+	Headers     []multipartHeader
+}
+
+type multipartHeader struct {
+	Name  string
+	Value string
+}
+
+// serializationWriterStream adapts a SerializationWriter to an io.Writer, writing each chunk as a raw byte array value.
+type serializationWriterStream struct {
+	writer serialization.SerializationWriter
+}
+
+// Write writes the chunk to the underlying SerializationWriter.
+func (s serializationWriterStream) Write(p []byte) (int, error) {
+	if err := s.writer.WriteByteArrayValue("", p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
 }
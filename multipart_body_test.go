@@ -1,6 +1,7 @@
 package abstractions
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/microsoft/kiota-abstractions-go/internal"
@@ -82,3 +83,195 @@ func TestItRemovesPart(t *testing.T) {
 }
 
 //serialize method is being tested in the serialization library
+
+type recordingSerializer struct {
+	internal.MockSerializer
+	content strings.Builder
+}
+
+func (r *recordingSerializer) WriteStringValue(key string, value *string) error {
+	if key != "" {
+		r.content.WriteString(key + ": ")
+	}
+	r.content.WriteString(*value + "\r\n")
+	return nil
+}
+
+func (r *recordingSerializer) WriteByteArrayValue(key string, value []byte) error {
+	r.content.Write(value)
+	return nil
+}
+
+func TestItStreamsReaderParts(t *testing.T) {
+	multipart := NewMultipartBody()
+	multipart.SetRequestAdapter(&MockRequestAdapter{
+		SerializationWriterFactory: &internal.MockSerializerFactory{},
+	})
+	err := multipart.AddOrReplacePart("file", "application/octet-stream", strings.NewReader("file content"))
+	assert.Nil(t, err)
+
+	serializer := &recordingSerializer{}
+	err = multipart.Serialize(serializer)
+	assert.Nil(t, err)
+	assert.Contains(t, serializer.content.String(), "Content-Disposition: form-data; name=\"file\"\r\n\r\nfile content\r\n--"+multipart.GetBoundary()+"--")
+}
+
+func TestItSerializesPartsInInsertionOrder(t *testing.T) {
+	multipart := NewMultipartBody()
+	multipart.SetRequestAdapter(&MockRequestAdapter{
+		SerializationWriterFactory: &internal.MockSerializerFactory{},
+	})
+	names := []string{"metadata", "file", "a", "z", "thumbnail"}
+	for _, name := range names {
+		assert.Nil(t, multipart.AddOrReplacePart(name, "text/plain", name))
+	}
+	assert.Nil(t, multipart.AddOrReplacePart("A", "text/plain", "replaced"))
+	assert.Equal(t, []string{"metadata", "file", "A", "z", "thumbnail"}, multipart.GetPartNames())
+
+	serializer := &recordingSerializer{}
+	assert.Nil(t, multipart.Serialize(serializer))
+	content := serializer.content.String()
+	previous := -1
+	for _, name := range []string{"metadata", "file", "A", "z", "thumbnail"} {
+		index := strings.Index(content, "name=\""+name+"\"")
+		assert.Greater(t, index, previous)
+		previous = index
+	}
+}
+
+func TestItSerializesFileNamesAndPartHeaders(t *testing.T) {
+	multipart := NewMultipartBody()
+	multipart.SetRequestAdapter(&MockRequestAdapter{
+		SerializationWriterFactory: &internal.MockSerializerFactory{},
+	})
+	err := multipart.AddOrReplacePartWithFileName("file", "image/png", "picture \"1\".png", []byte("png"))
+	assert.Nil(t, err)
+	assert.Nil(t, multipart.SetPartHeader("file", "Content-Transfer-Encoding", "binary"))
+	assert.Nil(t, multipart.SetPartHeader("file", "Content-ID", "<picture>"))
+	assert.Nil(t, multipart.SetPartHeader("file", "content-transfer-encoding", "8bit"))
+	assert.NotNil(t, multipart.SetPartHeader("file", "Content-Type", "text/plain"))
+	assert.NotNil(t, multipart.SetPartHeader("missing", "Content-ID", "<missing>"))
+
+	fileName, err := multipart.GetPartFileName("FILE")
+	assert.Nil(t, err)
+	assert.Equal(t, "picture \"1\".png", fileName)
+	header, err := multipart.GetPartHeader("file", "CONTENT-ID")
+	assert.Nil(t, err)
+	assert.Equal(t, "<picture>", header)
+
+	serializer := &recordingSerializer{}
+	assert.Nil(t, multipart.Serialize(serializer))
+	assert.Contains(t, serializer.content.String(), "Content-Type: image/png\r\nContent-Disposition: form-data; name=\"file\"; filename=\"picture \\\"1\\\".png\"\r\ncontent-transfer-encoding: 8bit\r\nContent-ID: <picture>\r\n\r\npng")
+}
+
+func TestItRejectsLineBreaksInPartHeaders(t *testing.T) {
+	multipart := NewMultipartBody()
+	for _, value := range []string{"a\rb", "a\nb", "a\x00b"} {
+		assert.NotNil(t, multipart.AddOrReplacePart(value, "text/plain", "content"))
+		assert.NotNil(t, multipart.AddOrReplacePart("part", "text/plain"+value, "content"))
+		assert.NotNil(t, multipart.AddOrReplacePartWithFileName("part", "text/plain", value, "content"))
+	}
+	assert.Empty(t, multipart.GetPartNames())
+
+	assert.Nil(t, multipart.AddOrReplacePart("part", "text/plain", "content"))
+	for _, value := range []string{"a\rb", "a\nb", "a\x00b"} {
+		assert.NotNil(t, multipart.SetPartHeader("part", value, "value"))
+		assert.NotNil(t, multipart.SetPartHeader("part", "X-Header", value))
+		assert.NotNil(t, multipart.SetPartContentId("part", value))
+	}
+	assert.NotNil(t, multipart.SetPartHeader("part", "X-Header: injected", "value"))
+	header, err := multipart.GetPartHeader("part", "X-Header")
+	assert.Nil(t, err)
+	assert.Empty(t, header)
+
+	_, err = escapeQuotedString("a\r\nb")
+	assert.NotNil(t, err)
+	escaped, err := escapeQuotedString("a \"b\"")
+	assert.Nil(t, err)
+	assert.Equal(t, "a \\\"b\\\"", escaped)
+}
+
+func TestItKeepsTheFileNameAndHeadersOfReplacedParts(t *testing.T) {
+	multipart := NewMultipartBody()
+	assert.Nil(t, multipart.AddOrReplacePartWithFileName("file", "image/png", "picture.png", []byte("png")))
+	assert.Nil(t, multipart.SetPartContentId("file", "picture"))
+
+	assert.Nil(t, multipart.AddOrReplacePart("FILE", "image/jpeg", []byte("jpeg")))
+	fileName, err := multipart.GetPartFileName("file")
+	assert.Nil(t, err)
+	assert.Equal(t, "picture.png", fileName)
+	header, err := multipart.GetPartHeader("file", "Content-ID")
+	assert.Nil(t, err)
+	assert.Equal(t, "<picture>", header)
+
+	assert.Nil(t, multipart.AddOrReplacePartWithFileName("file", "image/gif", "picture.gif", []byte("gif")))
+	fileName, err = multipart.GetPartFileName("file")
+	assert.Nil(t, err)
+	assert.Equal(t, "picture.gif", fileName)
+	header, err = multipart.GetPartHeader("file", "Content-ID")
+	assert.Nil(t, err)
+	assert.Equal(t, "<picture>", header)
+	value, err := multipart.GetPartValue("file")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("gif"), value)
+}
+
+func TestItSerializesARelatedBodyWithARootPart(t *testing.T) {
+	multipart := NewMultipartBodyWithSubtype(MultipartRelatedSubtype)
+	multipart.SetRequestAdapter(&MockRequestAdapter{
+		SerializationWriterFactory: &internal.MockSerializerFactory{},
+	})
+	assert.Nil(t, multipart.AddOrReplacePartWithFileName("attachment", "image/png", "picture.png", []byte("png")))
+	assert.Nil(t, multipart.SetPartContentId("attachment", "picture@example.com"))
+	reference, err := multipart.GetPartContentIdReference("attachment")
+	assert.Nil(t, err)
+	assert.Equal(t, "cid:picture@example.com", reference)
+	assert.Nil(t, multipart.AddOrReplacePart("manifest", "application/json", "{\"picture\":\""+reference+"\"}"))
+	assert.Nil(t, multipart.SetPartContentId("manifest", "<manifest@example.com>"))
+	assert.Nil(t, multipart.SetRootPart("manifest"))
+	assert.NotNil(t, multipart.SetRootPart("missing"))
+	assert.Equal(t, "manifest", multipart.GetRootPart())
+
+	serializer := &recordingSerializer{}
+	assert.Nil(t, multipart.Serialize(serializer))
+	content := serializer.content.String()
+	assert.True(t, strings.HasPrefix(content, "--"+multipart.GetBoundary()+"\r\nContent-Type: application/json\r\nContent-ID: <manifest@example.com>\r\n\r\n"))
+	assert.Contains(t, content, "Content-Type: image/png\r\nContent-Disposition: attachment; filename=\"picture.png\"\r\nContent-ID: <picture@example.com>\r\n\r\npng")
+	assert.NotContains(t, content, "form-data")
+	contentType, err := multipartContentType("multipart/related", multipart)
+	assert.Nil(t, err)
+	assert.Equal(t, "multipart/related; boundary="+multipart.GetBoundary()+"; type=\"application/json\"; start=\"<manifest@example.com>\"", contentType)
+}
+
+func TestItSerializesNestedMultipartBodies(t *testing.T) {
+	multipart := NewMultipartBodyWithSubtype(MultipartMixedSubtype)
+	multipart.SetRequestAdapter(&MockRequestAdapter{
+		SerializationWriterFactory: &internal.MockSerializerFactory{},
+	})
+	nested := NewMultipartBodyWithSubtype(MultipartMixedSubtype)
+	assert.Nil(t, nested.AddOrReplacePart("inner", "text/plain", []byte("inner content")))
+	assert.Nil(t, multipart.AddOrReplacePart("outer", "text/plain", "outer content"))
+	assert.Nil(t, multipart.AddOrReplacePart("nested", "multipart/mixed", nested))
+
+	serializer := &recordingSerializer{}
+	assert.Nil(t, multipart.Serialize(serializer))
+	content := serializer.content.String()
+	assert.Contains(t, content, "Content-Type: multipart/mixed; boundary="+nested.GetBoundary()+"\r\n\r\n--"+nested.GetBoundary()+"\r\nContent-Type: text/plain\r\n\r\ninner content\r\n--"+nested.GetBoundary()+"--")
+	assert.True(t, strings.HasSuffix(content, "--"+multipart.GetBoundary()+"--\r\n"))
+
+	factory := NewMultipartParseNodeFactoryWithContentType("multipart/mixed", serialization.NewParseNodeFactoryRegistry())
+	node, err := factory.GetRootParseNode("multipart/mixed; boundary="+multipart.GetBoundary(), []byte(content))
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(CreateMultipartBodyFromDiscriminatorValue)
+	assert.Nil(t, err)
+	body := result.(MultipartBody)
+	assert.Equal(t, MultipartMixedSubtype, body.GetSubtype())
+	assert.Equal(t, []string{"part0", "part1"}, body.GetPartNames())
+	value, err := body.GetPartValue("part1")
+	assert.Nil(t, err)
+	nestedResult, ok := value.(MultipartBody)
+	assert.True(t, ok)
+	innerValue, err := nestedResult.GetPartValue("part0")
+	assert.Nil(t, err)
+	assert.Equal(t, "inner content", innerValue)
+}
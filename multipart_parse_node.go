@@ -0,0 +1,415 @@
+package abstractions
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	s "github.com/microsoft/kiota-abstractions-go/serialization"
+)
+
+const multipartFormDataContentType = "multipart/form-data"
+
+// MultipartParseNodeFactory is a ParseNodeFactory for multipart content.
+// Each part is deserialized with the ParseNodeFactory registered for the part's content type.
+type MultipartParseNodeFactory struct {
+	contentType          string
+	partParseNodeFactory s.ParseNodeFactory
+}
+
+// NewMultipartParseNodeFactory creates a new MultipartParseNodeFactory for multipart/form-data content
+// deserializing the parts with the default parse node factory registry.
+func NewMultipartParseNodeFactory() *MultipartParseNodeFactory {
+	return NewMultipartParseNodeFactoryWithContentType(multipartFormDataContentType, s.DefaultParseNodeFactoryInstance)
+}
+
+// NewMultipartParseNodeFactoryWithContentType creates a new MultipartParseNodeFactory for the given multipart content type
+// deserializing the parts with the given parse node factory.
+func NewMultipartParseNodeFactoryWithContentType(contentType string, partParseNodeFactory s.ParseNodeFactory) *MultipartParseNodeFactory {
+	return &MultipartParseNodeFactory{
+		contentType:          contentType,
+		partParseNodeFactory: partParseNodeFactory,
+	}
+}
+
+// GetValidContentType returns the content type this factory's parse nodes can deserialize.
+func (m *MultipartParseNodeFactory) GetValidContentType() (string, error) {
+	return m.contentType, nil
+}
+
+// ReadsContentTypeParameters returns true, the boundary of the content is read from the parameters of the content type.
+func (m *MultipartParseNodeFactory) ReadsContentTypeParameters() bool {
+	return true
+}
+
+// GetRootParseNode returns a new ParseNode instance that is the root of the content.
+// The boundary is read from the content type parameters, an error is returned when the content type doesn't carry it.
+func (m *MultipartParseNodeFactory) GetRootParseNode(contentType string, content []byte) (s.ParseNode, error) {
+	if contentType == "" {
+		return nil, errors.New("contentType is required")
+	}
+	if content == nil {
+		return nil, errors.New("content is required")
+	}
+	return newMultipartParseNode(contentType, content, m.partParseNodeFactory)
+}
+
+func newMultipartParseNode(contentType string, content []byte, partParseNodeFactory s.ParseNodeFactory) (*multipartParseNode, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, err
+	}
+	subtype, isMultipart := strings.CutPrefix(mediaType, "multipart/")
+	if !isMultipart {
+		return nil, errors.New("the content type " + mediaType + " is not a multipart content type")
+	}
+	boundary := params["boundary"]
+	if boundary == "" {
+		return nil, errors.New("the content type " + mediaType + " has no boundary parameter")
+	}
+	parts, err := readMultipartParts(multipart.NewReader(bytes.NewReader(content), boundary))
+	if err != nil {
+		return nil, err
+	}
+	return &multipartParseNode{
+		boundary:             boundary,
+		subtype:              subtype,
+		start:                params["start"],
+		parts:                parts,
+		partParseNodeFactory: partParseNodeFactory,
+	}, nil
+}
+
+type multipartParsedPart struct {
+	name        string
+	contentType string
+	fileName    string
+	headers     []multipartHeader
+	content     []byte
+}
+
+func readMultipartParts(reader *multipart.Reader) ([]multipartParsedPart, error) {
+	parts := make([]multipartParsedPart, 0)
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			nameUnnamedParts(parts)
+			return parts, nil
+		} else if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		parsedPart := multipartParsedPart{
+			contentType: part.Header.Get(contentTypeHeader),
+			content:     content,
+		}
+		if parsedPart.contentType == "" {
+			// RFC 2046 section 5.1: the default content type of a part is text/plain
+			parsedPart.contentType = "text/plain"
+		}
+		if _, dispositionParams, err := mime.ParseMediaType(part.Header.Get(contentDispositionHeader)); err == nil {
+			parsedPart.name = dispositionParams["name"]
+			parsedPart.fileName = dispositionParams["filename"]
+		}
+		headerNames := make([]string, 0, len(part.Header))
+		for key := range part.Header {
+			if !strings.EqualFold(key, contentTypeHeader) && !strings.EqualFold(key, contentDispositionHeader) {
+				headerNames = append(headerNames, key)
+			}
+		}
+		sort.Strings(headerNames)
+		for _, key := range headerNames {
+			for _, value := range part.Header[key] {
+				parsedPart.headers = append(parsedPart.headers, multipartHeader{Name: key, Value: value})
+			}
+		}
+		if parsedPart.name == "" {
+			parsedPart.name = strings.Trim(part.Header.Get(contentIdHeader), "<>")
+		}
+		parts = append(parts, parsedPart)
+	}
+}
+
+// nameUnnamedParts names the parts without name or content id "part" followed by their index,
+// a suffix is added when another part already uses that name.
+func nameUnnamedParts(parts []multipartParsedPart) {
+	used := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		used[strings.ToLower(part.name)] = true
+	}
+	for i := range parts {
+		if parts[i].name != "" {
+			continue
+		}
+		name := "part" + strconv.Itoa(i)
+		for suffix := 1; used[name]; suffix++ {
+			name = "part" + strconv.Itoa(i) + "_" + strconv.Itoa(suffix)
+		}
+		used[name] = true
+		parts[i].name = name
+	}
+}
+
+// multipartParseNode is the root ParseNode of a multipart content, it can only be deserialized as an object.
+type multipartParseNode struct {
+	boundary                  string
+	subtype                   string
+	start                     string
+	parts                     []multipartParsedPart
+	partParseNodeFactory      s.ParseNodeFactory
+	onBeforeAssignFieldValues s.ParsableAction
+	onAfterAssignFieldValues  s.ParsableAction
+}
+
+var errMultipartParseNodeUnsupported = errors.New("multipart content can only be deserialized as an object")
+
+// GetChildNode returns the parse node of the part with the given name, deserialized with the parse node factory registered for the part's content type.
+func (m *multipartParseNode) GetChildNode(index string) (s.ParseNode, error) {
+	for _, part := range m.parts {
+		if strings.EqualFold(part.name, index) {
+			return m.getPartParseNode(part)
+		}
+	}
+	return nil, nil
+}
+
+func (m *multipartParseNode) getPartParseNode(part multipartParsedPart) (s.ParseNode, error) {
+	if m.partParseNodeFactory == nil {
+		return nil, errors.New("the part parse node factory cannot be nil")
+	}
+	return m.partParseNodeFactory.GetRootParseNode(part.contentType, part.content)
+}
+
+// getPartValue returns the value of the part: text content as a string, content with a registered parse node factory
+// as an untyped node, and any other content as a byte array. Errors deserializing content with a registered factory are returned.
+func (m *multipartParseNode) getPartValue(part multipartParsedPart) (any, error) {
+	mediaType, _, err := mime.ParseMediaType(part.contentType)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(mediaType, "text/") {
+		return string(part.content), nil
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		node, err := newMultipartParseNode(part.contentType, part.content, m.partParseNodeFactory)
+		if err != nil {
+			return nil, err
+		}
+		return node.GetObjectValue(CreateMultipartBodyFromDiscriminatorValue)
+	}
+	if m.partParseNodeFactory != nil && len(part.content) > 0 {
+		node, err := m.getPartParseNode(part)
+		var unregistered *s.UnregisteredContentTypeError
+		if errors.As(err, &unregistered) {
+			return part.content, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			return node.GetObjectValue(s.CreateUntypedNodeFromDiscriminatorValue)
+		}
+	}
+	return part.content, nil
+}
+
+// GetObjectValue returns the Parsable value from the node.
+// MultipartBody results are populated with every part, other models receive the parts matching their field deserializers.
+func (m *multipartParseNode) GetObjectValue(ctor s.ParsableFactory) (s.Parsable, error) {
+	if ctor == nil {
+		return nil, errors.New("constructor is nil")
+	}
+	result, err := ctor(m)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+	if m.onBeforeAssignFieldValues != nil {
+		if err := m.onBeforeAssignFieldValues(result); err != nil {
+			return nil, err
+		}
+	}
+	if body, ok := result.(MultipartBody); ok {
+		if err := m.populateMultipartBody(body); err != nil {
+			return nil, err
+		}
+	} else {
+		fields := result.GetFieldDeserializers()
+		for _, part := range m.parts {
+			deserializer, ok := fields[part.name]
+			if !ok {
+				continue
+			}
+			node, err := m.getPartParseNode(part)
+			if err != nil {
+				return nil, err
+			}
+			if err := deserializer(node); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if m.onAfterAssignFieldValues != nil {
+		if err := m.onAfterAssignFieldValues(result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (m *multipartParseNode) populateMultipartBody(body MultipartBody) error {
+	if concrete, ok := body.(*multipartBody); ok {
+		concrete.boundary = m.boundary
+		concrete.subtype = m.subtype
+	}
+	rootPart := ""
+	for _, part := range m.parts {
+		value, err := m.getPartValue(part)
+		if err != nil {
+			return err
+		}
+		if err := body.AddOrReplacePartWithFileName(part.name, part.contentType, part.fileName, value); err != nil {
+			return err
+		}
+		for _, header := range part.headers {
+			if err := body.SetPartHeader(part.name, header.Name, header.Value); err != nil {
+				return err
+			}
+			if m.start != "" && rootPart == "" && strings.EqualFold(header.Name, contentIdHeader) && header.Value == m.start {
+				rootPart = part.name
+			}
+		}
+	}
+	if rootPart != "" {
+		return body.SetRootPart(rootPart)
+	}
+	return nil
+}
+
+// GetCollectionOfObjectValues is not supported for multipart content.
+func (m *multipartParseNode) GetCollectionOfObjectValues(ctor s.ParsableFactory) ([]s.Parsable, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetCollectionOfPrimitiveValues is not supported for multipart content.
+func (m *multipartParseNode) GetCollectionOfPrimitiveValues(targetType string) ([]interface{}, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetCollectionOfEnumValues is not supported for multipart content.
+func (m *multipartParseNode) GetCollectionOfEnumValues(parser s.EnumFactory) ([]interface{}, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetStringValue is not supported for multipart content.
+func (m *multipartParseNode) GetStringValue() (*string, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetBoolValue is not supported for multipart content.
+func (m *multipartParseNode) GetBoolValue() (*bool, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetInt8Value is not supported for multipart content.
+func (m *multipartParseNode) GetInt8Value() (*int8, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetByteValue is not supported for multipart content.
+func (m *multipartParseNode) GetByteValue() (*byte, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetFloat32Value is not supported for multipart content.
+func (m *multipartParseNode) GetFloat32Value() (*float32, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetFloat64Value is not supported for multipart content.
+func (m *multipartParseNode) GetFloat64Value() (*float64, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetInt32Value is not supported for multipart content.
+func (m *multipartParseNode) GetInt32Value() (*int32, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetInt64Value is not supported for multipart content.
+func (m *multipartParseNode) GetInt64Value() (*int64, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetTimeValue is not supported for multipart content.
+func (m *multipartParseNode) GetTimeValue() (*time.Time, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetISODurationValue is not supported for multipart content.
+func (m *multipartParseNode) GetISODurationValue() (*s.ISODuration, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetTimeOnlyValue is not supported for multipart content.
+func (m *multipartParseNode) GetTimeOnlyValue() (*s.TimeOnly, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetDateOnlyValue is not supported for multipart content.
+func (m *multipartParseNode) GetDateOnlyValue() (*s.DateOnly, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetUUIDValue is not supported for multipart content.
+func (m *multipartParseNode) GetUUIDValue() (*uuid.UUID, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetEnumValue is not supported for multipart content.
+func (m *multipartParseNode) GetEnumValue(parser s.EnumFactory) (interface{}, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetByteArrayValue is not supported for multipart content.
+func (m *multipartParseNode) GetByteArrayValue() ([]byte, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetRawValue is not supported for multipart content.
+func (m *multipartParseNode) GetRawValue() (interface{}, error) {
+	return nil, errMultipartParseNodeUnsupported
+}
+
+// GetOnBeforeAssignFieldValues returns a callback invoked before the node is deserialized.
+func (m *multipartParseNode) GetOnBeforeAssignFieldValues() s.ParsableAction {
+	return m.onBeforeAssignFieldValues
+}
+
+// SetOnBeforeAssignFieldValues sets a callback invoked before the node is deserialized.
+func (m *multipartParseNode) SetOnBeforeAssignFieldValues(action s.ParsableAction) error {
+	m.onBeforeAssignFieldValues = action
+	return nil
+}
+
+// GetOnAfterAssignFieldValues returns a callback invoked after the node is deserialized.
+func (m *multipartParseNode) GetOnAfterAssignFieldValues() s.ParsableAction {
+	return m.onAfterAssignFieldValues
+}
+
+// SetOnAfterAssignFieldValues sets a callback invoked after the node is deserialized.
+func (m *multipartParseNode) SetOnAfterAssignFieldValues(action s.ParsableAction) error {
+	m.onAfterAssignFieldValues = action
+	return nil
+}
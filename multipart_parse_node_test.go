@@ -0,0 +1,172 @@
+package abstractions
+
+import (
+	"encoding/json"
+	"testing"
+
+	s "github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+const multipartResponseContent = "--boundary\r\n" +
+	"Content-Type: application/json\r\n" +
+	"Content-Disposition: form-data; name=\"metadata\"\r\n" +
+	"\r\n" +
+	"{\"id\":\"123\"}\r\n" +
+	"--boundary\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"Content-Disposition: form-data; name=\"description\"\r\n" +
+	"\r\n" +
+	"a text part\r\n" +
+	"--boundary\r\n" +
+	"Content-Type: application/octet-stream\r\n" +
+	"Content-Disposition: form-data; name=\"file\"; filename=\"file.bin\"\r\n" +
+	"Content-Transfer-Encoding: binary\r\n" +
+	"\r\n" +
+	"binary\r\n" +
+	"--boundary--\r\n"
+
+type nativeJsonParseNodeFactory struct {
+}
+
+func (f *nativeJsonParseNodeFactory) GetValidContentType() (string, error) {
+	return "application/json", nil
+}
+
+func (f *nativeJsonParseNodeFactory) GetRootParseNode(contentType string, content []byte) (s.ParseNode, error) {
+	var value any
+	if err := json.Unmarshal(content, &value); err != nil {
+		return nil, err
+	}
+	return s.NewNativeParseNode(value), nil
+}
+
+func newMultipartTestParseNodeFactory() *MultipartParseNodeFactory {
+	registry := s.NewParseNodeFactoryRegistry()
+	registry.ContentTypeAssociatedFactories["application/json"] = &nativeJsonParseNodeFactory{}
+	return NewMultipartParseNodeFactoryWithContentType("multipart/form-data", registry)
+}
+
+func TestItDeserializesAMultipartBody(t *testing.T) {
+	factory := newMultipartTestParseNodeFactory()
+
+	node, err := factory.GetRootParseNode("multipart/form-data; boundary=boundary", []byte(multipartResponseContent))
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(CreateMultipartBodyFromDiscriminatorValue)
+	assert.Nil(t, err)
+	body, ok := result.(MultipartBody)
+	assert.True(t, ok)
+
+	assert.Equal(t, "boundary", body.GetBoundary())
+	assert.Equal(t, []string{"metadata", "description", "file"}, body.GetPartNames())
+
+	value, err := body.GetPartValue("metadata")
+	assert.Nil(t, err)
+	metadata, ok := value.(*s.UntypedObject)
+	assert.True(t, ok)
+	id, ok := metadata.GetValue()["id"].(*s.UntypedString)
+	assert.True(t, ok)
+	assert.Equal(t, "123", *id.GetValue())
+
+	value, err = body.GetPartValue("description")
+	assert.Nil(t, err)
+	assert.Equal(t, "a text part", value)
+
+	value, err = body.GetPartValue("file")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("binary"), value)
+	fileName, err := body.GetPartFileName("file")
+	assert.Nil(t, err)
+	assert.Equal(t, "file.bin", fileName)
+	header, err := body.GetPartHeader("file", "Content-Transfer-Encoding")
+	assert.Nil(t, err)
+	assert.Equal(t, "binary", header)
+}
+
+func TestItReadsTheBoundaryThroughTheRegistry(t *testing.T) {
+	registry := s.NewParseNodeFactoryRegistry()
+	registry.ContentTypeAssociatedFactories["multipart/form-data"] = newMultipartTestParseNodeFactory()
+
+	// a preamble precedes the first delimiter
+	content := "--not-the-boundary\r\n" + multipartResponseContent
+	node, err := registry.GetRootParseNode("multipart/form-data; boundary=boundary", []byte(content))
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(CreateMultipartBodyFromDiscriminatorValue)
+	assert.Nil(t, err)
+	assert.Equal(t, "boundary", result.(MultipartBody).GetBoundary())
+	assert.Equal(t, 3, len(result.(MultipartBody).GetPartNames()))
+
+	_, err = registry.GetRootParseNode("multipart/form-data", []byte(multipartResponseContent))
+	assert.EqualError(t, err, "the content type multipart/form-data has no boundary parameter")
+}
+
+func TestItReturnsTheErrorsOfThePartFactories(t *testing.T) {
+	factory := newMultipartTestParseNodeFactory()
+	content := "--boundary\r\n" +
+		"Content-Type: application/json\r\n" +
+		"Content-Disposition: form-data; name=\"metadata\"\r\n" +
+		"\r\n" +
+		"{\"id\":\r\n" +
+		"--boundary\r\n" +
+		"Content-Type: image/png\r\n" +
+		"Content-Disposition: form-data; name=\"picture\"\r\n" +
+		"\r\n" +
+		"png\r\n" +
+		"--boundary--\r\n"
+	node, err := factory.GetRootParseNode("multipart/form-data; boundary=boundary", []byte(content))
+	assert.Nil(t, err)
+	_, err = node.GetObjectValue(CreateMultipartBodyFromDiscriminatorValue)
+	var syntaxError *json.SyntaxError
+	assert.ErrorAs(t, err, &syntaxError)
+}
+
+func TestItCallsTheAssignFieldValuesHooks(t *testing.T) {
+	factory := newMultipartTestParseNodeFactory()
+	node, err := factory.GetRootParseNode("multipart/form-data; boundary=boundary", []byte(multipartResponseContent))
+	assert.Nil(t, err)
+	calls := 0
+	hook := func(parsable s.Parsable) error {
+		calls++
+		return nil
+	}
+	assert.Nil(t, node.SetOnBeforeAssignFieldValues(hook))
+	assert.Nil(t, node.SetOnAfterAssignFieldValues(hook))
+	_, err = node.GetObjectValue(CreateMultipartBodyFromDiscriminatorValue)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestItRejectsNonMultipartContent(t *testing.T) {
+	factory := NewMultipartParseNodeFactory()
+	_, err := factory.GetRootParseNode("application/json", []byte("{}"))
+	assert.NotNil(t, err)
+	_, err = factory.GetRootParseNode("multipart/form-data", []byte("no boundary"))
+	assert.NotNil(t, err)
+}
+
+func TestItNamesUnnamedPartsWithoutCollisions(t *testing.T) {
+	factory := newMultipartTestParseNodeFactory()
+	content := "--boundary\r\n" +
+		"\r\n" +
+		"unnamed\r\n" +
+		"--boundary\r\n" +
+		"Content-Disposition: form-data; name=\"Part0\"\r\n" +
+		"\r\n" +
+		"named\r\n" +
+		"--boundary\r\n" +
+		"\r\n" +
+		"other\r\n" +
+		"--boundary--\r\n"
+	node, err := factory.GetRootParseNode("multipart/form-data; boundary=boundary", []byte(content))
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(CreateMultipartBodyFromDiscriminatorValue)
+	assert.Nil(t, err)
+	body := result.(MultipartBody)
+	assert.Equal(t, 3, len(body.GetPartNames()))
+
+	for name, expected := range map[string]string{"part0_1": "unnamed", "part0": "named", "part2": "other"} {
+		value, err := body.GetPartValue(name)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, value)
+	}
+}
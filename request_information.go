@@ -1,8 +1,10 @@
 package abstractions
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"time"
 
 	"reflect"
@@ -33,6 +35,12 @@ type RequestInformation struct {
 	QueryParametersAny map[string]any
 	// The Request Body.
 	Content []byte
+	// The Request Body as a stream, takes precedence over Content when set.
+	ContentStream io.Reader
+	// The length in bytes of ContentStream, -1 or 0 when unknown so a stream set without its length is not sent as an empty body.
+	ContentStreamLength int64
+	// Returns a new reader positioned at the start of the body so ContentStream can be replayed, nil when the stream cannot be replayed.
+	ContentStreamRewind func() (io.Reader, error)
 	// The path parameters to use for the URL template when generating the URI.
 	// Deprecated: use PathParametersAny instead
 	PathParameters map[string]string
@@ -40,11 +48,16 @@ type RequestInformation struct {
 	PathParametersAny map[string]any
 	// The Url template for the current request.
 	UrlTemplate string
-	options     map[string]RequestOption
+	// Whether GetUri validates the parameters against the URL template and fails when required ones are missing or unknown ones are set.
+	StrictUrlTemplate bool
+	options           map[string]RequestOption
 }
 
 const raw_url_key = "request-raw-url"
 
+// DefaultStrictUrlTemplate is the value of StrictUrlTemplate for the requests created by NewRequestInformation.
+var DefaultStrictUrlTemplate = false
+
 // NewRequestInformation creates a new RequestInformation object with default values.
 func NewRequestInformation() *RequestInformation {
 	return &RequestInformation{
@@ -54,6 +67,7 @@ func NewRequestInformation() *RequestInformation {
 		options:            make(map[string]RequestOption),
 		PathParameters:     make(map[string]string),
 		PathParametersAny:  make(map[string]any),
+		StrictUrlTemplate:  DefaultStrictUrlTemplate,
 	}
 }
 
@@ -103,21 +117,13 @@ func (request *RequestInformation) GetUri() (*u.URL, error) {
 		if !baseurlExists && strings.Contains(strings.ToLower(request.UrlTemplate), "{+baseurl}") {
 			return nil, errors.New("pathParameters must contain a value for \"baseurl\" for the url to be built")
 		}
-
-		substitutions := make(map[string]any)
-		for key, value := range request.PathParameters {
-			substitutions[key] = request.sanitizeValue(value)
-		}
-		for key, value := range request.PathParametersAny {
-			substitutions[key] = request.normalizeParameters(reflect.ValueOf(value), request.sanitizeValue(value), false)
-		}
-		for key, value := range request.QueryParameters {
-			substitutions[key] = request.sanitizeValue(value)
-		}
-		for key, value := range request.QueryParametersAny {
-			substitutions[key] = request.sanitizeValue(value)
+		if request.StrictUrlTemplate {
+			if err := request.ValidateUrlTemplate(); err != nil {
+				return nil, err
+			}
 		}
-		url, err := stduritemplate.Expand(request.UrlTemplate, substitutions)
+
+		url, err := stduritemplate.Expand(request.UrlTemplate, request.getSubstitutions())
 		if err != nil {
 			return nil, err
 		}
@@ -126,6 +132,33 @@ func (request *RequestInformation) GetUri() (*u.URL, error) {
 	}
 }
 
+// ValidateUrlTemplate checks the path and query parameters against the variables of the URL template, it returns a *UrlTemplateValidationError
+// listing the required variables without a value and the parameters the URL template does not define. Requests with a raw URL are valid.
+func (request *RequestInformation) ValidateUrlTemplate() error {
+	if request.uri != nil || request.PathParameters[raw_url_key] != "" {
+		return nil
+	}
+	return validateUrlTemplateParameters(request.UrlTemplate, request.getSubstitutions())
+}
+
+// getSubstitutions returns the values of the path and query parameters the URL template is expanded with.
+func (request *RequestInformation) getSubstitutions() map[string]any {
+	substitutions := make(map[string]any)
+	for key, value := range request.PathParameters {
+		substitutions[key] = request.sanitizeValue(value)
+	}
+	for key, value := range request.PathParametersAny {
+		substitutions[key] = request.normalizeParameters(reflect.ValueOf(value), request.sanitizeValue(value), false)
+	}
+	for key, value := range request.QueryParameters {
+		substitutions[key] = request.sanitizeValue(value)
+	}
+	for key, value := range request.QueryParametersAny {
+		substitutions[key] = request.sanitizeValue(value)
+	}
+	return substitutions
+}
+
 func castItem[T any, R interface{}](collection []T, mutator func(t T) R) []R {
 	if len(collection) > 0 {
 		cast := make([]R, len(collection))
@@ -217,6 +250,30 @@ func (request *RequestInformation) sanitizeValue(value any) any {
 		return castItem(v, func(v s.DateOnly) string {
 			return v.String()
 		})
+	case *s.ISOInterval:
+		return v.String()
+	case s.ISOInterval:
+		return v.String()
+	case []*s.ISOInterval:
+		return castItem(v, func(v *s.ISOInterval) string {
+			return v.String()
+		})
+	case []s.ISOInterval:
+		return castItem(v, func(v s.ISOInterval) string {
+			return v.String()
+		})
+	case *s.ISORecurrence:
+		return v.String()
+	case s.ISORecurrence:
+		return v.String()
+	case []*s.ISORecurrence:
+		return castItem(v, func(v *s.ISORecurrence) string {
+			return v.String()
+		})
+	case []s.ISORecurrence:
+		return castItem(v, func(v s.ISORecurrence) string {
+			return v.String()
+		})
 	case map[string]*string:
 		// Map-style query parameter (nullable values): drop nil entries per
 		// RFC 6570 §2.3 "undefined" semantics; dereference remaining pointers.
@@ -259,6 +316,26 @@ func (request *RequestInformation) SetUri(url u.URL) {
 	}
 }
 
+// SetParametersFromUri matches the URL against the URL template of the request and replaces the path and query parameters with the values it holds,
+// so the URI is built from the URL template. Path parameters holding a string are also set in PathParameters.
+func (request *RequestInformation) SetParametersFromUri(url u.URL) error {
+	match, err := MatchUrlTemplate(request.UrlTemplate, url)
+	if err != nil {
+		return err
+	}
+	request.uri = nil
+	request.PathParameters = make(map[string]string)
+	for key, value := range match.PathParametersAny {
+		if str, ok := value.(string); ok {
+			request.PathParameters[key] = str
+		}
+	}
+	request.PathParametersAny = match.PathParametersAny
+	request.QueryParameters = make(map[string]string)
+	request.QueryParametersAny = match.QueryParametersAny
+	return nil
+}
+
 // AddRequestOptions adds an option to the request to be read by the middleware infrastructure.
 func (request *RequestInformation) AddRequestOptions(options []RequestOption) {
 	if options == nil {
@@ -297,12 +374,96 @@ func (request *RequestInformation) SetStreamContent(content []byte) {
 
 // SetStreamContentAndContentType sets the request body to a binary stream with the specified content type.
 func (request *RequestInformation) SetStreamContentAndContentType(content []byte, contentType string) {
+	request.clearContentStream()
 	request.Content = content
 	if request.Headers != nil {
 		request.Headers.Add(contentTypeHeader, contentType)
 	}
 }
 
+// SetStreamContentFromReader sets the request body to a stream with the specified content type without buffering it in memory.
+// length is the number of bytes the reader produces, -1 when unknown.
+// rewind returns a new reader positioned at the start of the body so it can be replayed on retries, it can be nil.
+// When rewind is nil and the reader implements io.Seeker, the reader is rewound by seeking back to its current position.
+func (request *RequestInformation) SetStreamContentFromReader(reader io.Reader, length int64, rewind func() (io.Reader, error), contentType string) error {
+	if reader == nil {
+		return errors.New("reader cannot be nil")
+	}
+	if rewind == nil {
+		if seeker, ok := reader.(io.Seeker); ok {
+			offset, err := seeker.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return err
+			}
+			rewind = func() (io.Reader, error) {
+				if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+					return nil, err
+				}
+				return reader, nil
+			}
+		}
+	}
+	request.Content = nil
+	request.ContentStream = reader
+	request.ContentStreamLength = length
+	request.ContentStreamRewind = rewind
+	if request.Headers != nil {
+		request.Headers.Add(contentTypeHeader, contentType)
+	}
+	return nil
+}
+
+// GetContentStream returns the request body as a stream along with its length in bytes, -1 when unknown.
+// The reader is nil when the request has no body.
+func (request *RequestInformation) GetContentStream() (io.Reader, int64) {
+	if request.ContentStream != nil {
+		if request.ContentStreamLength == 0 {
+			return request.ContentStream, -1
+		}
+		return request.ContentStream, request.ContentStreamLength
+	} else if request.Content != nil {
+		return bytes.NewReader(request.Content), int64(len(request.Content))
+	}
+	return nil, 0
+}
+
+// RewindContentStream resets the request body stream to its start so the request can be sent again.
+// It returns an error when the body is a stream that cannot be replayed.
+func (request *RequestInformation) RewindContentStream() error {
+	if request.ContentStream == nil {
+		return nil
+	}
+	if request.ContentStreamRewind == nil {
+		return errors.New("the content stream cannot be rewound")
+	}
+	reader, err := request.ContentStreamRewind()
+	if err != nil {
+		return err
+	} else if reader == nil {
+		return errors.New("the rewound content stream cannot be nil")
+	}
+	// the previous stream is closed so a producer blocked on a partly read pipe stops
+	if closer, ok := request.ContentStream.(io.Closer); ok && !sameReader(request.ContentStream, reader) {
+		if err := closer.Close(); err != nil {
+			return err
+		}
+	}
+	request.ContentStream = reader
+	return nil
+}
+
+// sameReader returns whether both readers are the same value, as when a seekable stream is rewound in place.
+func sameReader(a io.Reader, b io.Reader) bool {
+	typeOfA := reflect.TypeOf(a)
+	return typeOfA == reflect.TypeOf(b) && typeOfA.Comparable() && a == b
+}
+
+func (request *RequestInformation) clearContentStream() {
+	request.ContentStream = nil
+	request.ContentStreamLength = 0
+	request.ContentStreamRewind = nil
+}
+
 func (request *RequestInformation) setContentAndContentType(writer s.SerializationWriter, contentType string) error {
 	content, err := writer.GetSerializedContent()
 	if err != nil {
@@ -310,6 +471,7 @@ func (request *RequestInformation) setContentAndContentType(writer s.Serializati
 	} else if content == nil {
 		return errors.New("content cannot be nil")
 	}
+	request.clearContentStream()
 	request.Content = content
 	if request.Headers != nil {
 		request.Headers.TryAdd(contentTypeHeader, contentType)
@@ -317,7 +479,7 @@ func (request *RequestInformation) setContentAndContentType(writer s.Serializati
 	return nil
 }
 
-func (request *RequestInformation) getSerializationWriter(requestAdapter RequestAdapter, contentType string, items ...interface{}) (s.SerializationWriter, error) {
+func (request *RequestInformation) getSerializationWriterFactory(requestAdapter RequestAdapter, contentType string, items ...interface{}) (s.SerializationWriterFactory, error) {
 	if contentType == "" {
 		return nil, errors.New("content type cannot be empty")
 	} else if requestAdapter == nil {
@@ -329,6 +491,14 @@ func (request *RequestInformation) getSerializationWriter(requestAdapter Request
 	if factory == nil {
 		return nil, errors.New("factory cannot be nil")
 	}
+	return factory, nil
+}
+
+func (request *RequestInformation) getSerializationWriter(requestAdapter RequestAdapter, contentType string, items ...interface{}) (s.SerializationWriter, error) {
+	factory, err := request.getSerializationWriterFactory(requestAdapter, contentType, items...)
+	if err != nil {
+		return nil, err
+	}
 	writer, err := factory.GetSerializationWriter(contentType)
 	if err != nil {
 		return nil, err
@@ -348,6 +518,8 @@ func (r *RequestInformation) setRequestType(result interface{}, span trace.Span)
 const observabilityTracerName = "github.com/microsoft/kiota-abstractions-go"
 
 // SetContentFromParsable sets the request body from a model with the specified content type.
+// The model is serialized into Content, which request adapters and middlewares read directly,
+// use SetStreamContentFromParsable to stream large models instead of buffering them.
 func (request *RequestInformation) SetContentFromParsable(ctx context.Context, requestAdapter RequestAdapter, contentType string, item s.Parsable) error {
 	_, span := otel.GetTracerProvider().Tracer(observabilityTracerName).Start(ctx, "SetContentFromParsable")
 	defer span.End()
@@ -359,7 +531,11 @@ func (request *RequestInformation) SetContentFromParsable(ctx context.Context, r
 	}
 	defer writer.Close()
 	if multipartBody, ok := item.(MultipartBody); ok {
-		contentType += "; boundary=" + multipartBody.GetBoundary()
+		contentType, err = multipartContentType(contentType, multipartBody)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
 		multipartBody.SetRequestAdapter(requestAdapter)
 	}
 	request.setRequestType(item, span)
@@ -376,6 +552,106 @@ func (request *RequestInformation) SetContentFromParsable(ctx context.Context, r
 	return nil
 }
 
+// SetStreamContentFromParsable sets the request body from a model with the specified content type.
+// The model is serialized into the request body stream as it is read instead of being buffered beforehand,
+// serialization errors are returned by the reader. The stream can be rewound, which serializes the model again,
+// the io.Reader parts of multipart bodies are seeked back to their offset and the rewind fails when they are not an io.Seeker.
+func (request *RequestInformation) SetStreamContentFromParsable(ctx context.Context, requestAdapter RequestAdapter, contentType string, item s.Parsable) error {
+	_, span := otel.GetTracerProvider().Tracer(observabilityTracerName).Start(ctx, "SetStreamContentFromParsable")
+	defer span.End()
+
+	factory, err := request.getSerializationWriterFactory(requestAdapter, contentType, item)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	bodyContentType := contentType
+	rewindReaders := func() error { return nil }
+	if multipartBody, ok := item.(MultipartBody); ok {
+		bodyContentType, err = multipartContentType(bodyContentType, multipartBody)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		// the reader parts are drained when the body is serialized and must be rewound before serializing it again
+		rewindReaders, err = multipartReadersRewinder(multipartBody)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		multipartBody.SetRequestAdapter(requestAdapter)
+	}
+	request.setRequestType(item, span)
+	produce := func(w io.Writer) error {
+		writer, err := s.NewStreamSerializationWriter(factory, contentType, w)
+		if err != nil {
+			return err
+		}
+		if err := writer.WriteObjectValue("", item); err != nil {
+			writer.Close()
+			return err
+		}
+		return writer.Close()
+	}
+	current := newLazyPipeReader(produce)
+	request.Content = nil
+	request.ContentStream = current
+	request.ContentStreamLength = -1
+	request.ContentStreamRewind = func() (io.Reader, error) {
+		// the previous serialization is stopped before the readers it is copying from are rewound
+		if err := current.Close(); err != nil {
+			return nil, err
+		}
+		if err := rewindReaders(); err != nil {
+			return nil, err
+		}
+		current = newLazyPipeReader(produce)
+		return current, nil
+	}
+	if request.Headers != nil {
+		request.Headers.TryAdd(contentTypeHeader, bodyContentType)
+	}
+	return nil
+}
+
+// lazyPipeReader runs its producer in a goroutine the first time it is read, piping the produced bytes to the reader.
+type lazyPipeReader struct {
+	produce func(w io.Writer) error
+	reader  *io.PipeReader
+	// done is closed once the producer returned
+	done chan struct{}
+}
+
+func newLazyPipeReader(produce func(w io.Writer) error) *lazyPipeReader {
+	return &lazyPipeReader{
+		produce: produce,
+	}
+}
+
+// Read reads the produced bytes, starting the producer on the first call.
+func (l *lazyPipeReader) Read(p []byte) (int, error) {
+	if l.reader == nil {
+		reader, writer := io.Pipe()
+		l.reader = reader
+		l.done = make(chan struct{})
+		go func() {
+			defer close(l.done)
+			writer.CloseWithError(l.produce(writer))
+		}()
+	}
+	return l.reader.Read(p)
+}
+
+// Close closes the reader, which stops the producer if it is running, and waits for the producer to return.
+func (l *lazyPipeReader) Close() error {
+	if l.reader == nil {
+		return nil
+	}
+	err := l.reader.Close()
+	<-l.done
+	return err
+}
+
 // SetContentFromParsableCollection sets the request body from a model with the specified content type.
 func (request *RequestInformation) SetContentFromParsableCollection(ctx context.Context, requestAdapter RequestAdapter, contentType string, items []s.Parsable) error {
 	_, span := otel.GetTracerProvider().Tracer(observabilityTracerName).Start(ctx, "SetContentFromParsableCollection")
@@ -2,6 +2,8 @@ package abstractions
 
 import (
 	"context"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -681,3 +683,200 @@ func TestItOmitsNilSanitizedValuesInMapAnyQueryParameter(t *testing.T) {
 	uriStr := uri.String()
 	assert.Equal(t, "http://localhost/articles?include=author", uriStr)
 }
+
+func TestItSetsStreamContentFromReader(t *testing.T) {
+	requestInformation := NewRequestInformation()
+	requestInformation.Content = []byte("previous")
+
+	err := requestInformation.SetStreamContentFromReader(strings.NewReader("content"), 7, nil, "text/plain")
+	assert.Nil(t, err)
+	assert.Nil(t, requestInformation.Content)
+	assert.Equal(t, "text/plain", requestInformation.Headers.Get("Content-Type")[0])
+
+	reader, length := requestInformation.GetContentStream()
+	assert.Equal(t, int64(7), length)
+	content, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(content))
+
+	err = requestInformation.RewindContentStream()
+	assert.Nil(t, err)
+	reader, _ = requestInformation.GetContentStream()
+	content, err = io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(content))
+}
+
+func TestItDoesNotRewindANonReplayableStream(t *testing.T) {
+	requestInformation := NewRequestInformation()
+	err := requestInformation.SetStreamContentFromReader(io.MultiReader(strings.NewReader("content")), -1, nil, "text/plain")
+	assert.Nil(t, err)
+	assert.NotNil(t, requestInformation.RewindContentStream())
+}
+
+func TestItReturnsByteContentAsAStream(t *testing.T) {
+	requestInformation := NewRequestInformation()
+	requestInformation.SetStreamContentAndContentType([]byte("content"), "text/plain")
+	assert.Nil(t, requestInformation.RewindContentStream())
+
+	reader, length := requestInformation.GetContentStream()
+	assert.Equal(t, int64(7), length)
+	content, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(content))
+}
+
+func TestItSetsStreamContentFromParsable(t *testing.T) {
+	requestInformation := NewRequestInformation()
+	callsCounter := make(map[string]int)
+	requestAdapter := &MockRequestAdapter{
+		SerializationWriterFactory: &internal.MockSerializerFactory{
+			SerializationWriter: &internal.MockSerializer{
+				CallsCounter: callsCounter,
+			},
+		},
+	}
+
+	record := internal.CallRecord{}
+	err := requestInformation.SetStreamContentFromParsable(context.Background(), requestAdapter, "application/json", &record)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, callsCounter["WriteObjectValue"])
+	assert.Equal(t, "application/json", requestInformation.Headers.Get("Content-Type")[0])
+
+	reader, length := requestInformation.GetContentStream()
+	assert.Equal(t, int64(-1), length)
+	content, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(content))
+	assert.Equal(t, 1, callsCounter["WriteObjectValue"])
+
+	err = requestInformation.RewindContentStream()
+	assert.Nil(t, err)
+	reader, _ = requestInformation.GetContentStream()
+	content, err = io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(content))
+	assert.Equal(t, 2, callsCounter["WriteObjectValue"])
+}
+
+func TestItTreatsAZeroContentStreamLengthAsUnknown(t *testing.T) {
+	requestInformation := NewRequestInformation()
+	requestInformation.ContentStream = strings.NewReader("content")
+
+	_, length := requestInformation.GetContentStream()
+	assert.Equal(t, int64(-1), length)
+}
+
+func TestItRewindsTheReaderPartsOfMultipartBodies(t *testing.T) {
+	requestAdapter := &MockRequestAdapter{
+		SerializationWriterFactory: &internal.MockSerializerFactory{
+			SerializationWriter: &internal.MockSerializer{
+				CallsCounter: make(map[string]int),
+			},
+		},
+	}
+	file := strings.NewReader("file content")
+	nested := NewMultipartBody()
+	assert.Nil(t, nested.AddOrReplacePart("file", "application/octet-stream", file))
+	multipartBody := NewMultipartBody()
+	assert.Nil(t, multipartBody.AddOrReplacePart("metadata", "text/plain", "metadata"))
+	assert.Nil(t, multipartBody.AddOrReplacePart("attachments", "multipart/mixed", nested))
+
+	requestInformation := NewRequestInformation()
+	assert.Nil(t, requestInformation.SetStreamContentFromParsable(context.Background(), requestAdapter, "multipart/form-data", multipartBody))
+	reader, _ := requestInformation.GetContentStream()
+	_, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	// the first send drains the reader part
+	_, err = io.ReadAll(file)
+	assert.Nil(t, err)
+
+	assert.Nil(t, requestInformation.RewindContentStream())
+	content, err := io.ReadAll(file)
+	assert.Nil(t, err)
+	assert.Equal(t, "file content", string(content))
+}
+
+func TestItDoesNotRewindMultipartBodiesWithNonSeekableReaderParts(t *testing.T) {
+	requestAdapter := &MockRequestAdapter{
+		SerializationWriterFactory: &internal.MockSerializerFactory{
+			SerializationWriter: &internal.MockSerializer{
+				CallsCounter: make(map[string]int),
+			},
+		},
+	}
+	multipartBody := NewMultipartBody()
+	assert.Nil(t, multipartBody.AddOrReplacePart("file", "application/octet-stream", io.MultiReader(strings.NewReader("file content"))))
+
+	requestInformation := NewRequestInformation()
+	assert.Nil(t, requestInformation.SetStreamContentFromParsable(context.Background(), requestAdapter, "multipart/form-data", multipartBody))
+	assert.EqualError(t, requestInformation.RewindContentStream(), "the reader of the part file cannot be rewound")
+}
+
+func TestItNormalizesOnStandardizedIntervalParams(t *testing.T) {
+	interval, err := s.ParseISOInterval("2024-01-01/P1M")
+	assert.Nil(t, err)
+	recurrence, err := s.ParseISORecurrence("R5/2024-01-01T00:00:00Z/P1D")
+	assert.Nil(t, err)
+
+	requestInformation := prepareNormalizedStdTest([]s.ISOInterval{*interval}, *recurrence, []*s.ISORecurrence{recurrence}, interval)
+	resultUri, err := requestInformation.GetUri()
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost/array/2024-01-01%2FP1M/single/R5%2F2024-01-01T00%3A00%3A00Z%2FP1D/referenceArray/R5%2F2024-01-01T00%3A00%3A00Z%2FP1D/referenceValue/2024-01-01%2FP1M", resultUri.String())
+}
+
+func TestItClosesThePartlyReadStreamWhenRewinding(t *testing.T) {
+	requestInformation := NewRequestInformation()
+	produced := make(chan error, 2)
+	produce := func(w io.Writer) error {
+		chunk := []byte(strings.Repeat("a", 1024))
+		for i := 0; i < 1024; i++ {
+			if _, err := w.Write(chunk); err != nil {
+				produced <- err
+				return err
+			}
+		}
+		produced <- nil
+		return nil
+	}
+	err := requestInformation.SetStreamContentFromReader(newLazyPipeReader(produce), -1, func() (io.Reader, error) {
+		return newLazyPipeReader(produce), nil
+	}, "text/plain")
+	assert.Nil(t, err)
+
+	reader, _ := requestInformation.GetContentStream()
+	_, err = reader.Read(make([]byte, 10))
+	assert.Nil(t, err)
+	assert.Nil(t, requestInformation.RewindContentStream())
+
+	select {
+	case err := <-produced:
+		assert.Equal(t, io.ErrClosedPipe, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the producer of the previous stream is still running")
+	}
+}
+
+type closeCountingReader struct {
+	*strings.Reader
+	closed int
+}
+
+func (c *closeCountingReader) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestItDoesNotCloseAStreamRewoundInPlace(t *testing.T) {
+	requestInformation := NewRequestInformation()
+	reader := &closeCountingReader{Reader: strings.NewReader("content")}
+	assert.Nil(t, requestInformation.SetStreamContentFromReader(reader, 7, nil, "text/plain"))
+	_, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+
+	assert.Nil(t, requestInformation.RewindContentStream())
+	assert.Equal(t, 0, reader.closed)
+	content, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(content))
+}
@@ -1,6 +1,9 @@
 package serialization
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 )
@@ -37,3 +40,137 @@ func NewDateOnly(t time.Time) *DateOnly {
 		time: t,
 	}
 }
+
+// ToTime returns the midnight of the date in the location, UTC is used when the location is nil.
+func (t DateOnly) ToTime(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	year, month, day := t.time.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// Date returns the year, month and day of the date.
+func (t DateOnly) Date() (year int, month time.Month, day int) {
+	return t.time.Date()
+}
+
+// Weekday returns the day of the week of the date.
+func (t DateOnly) Weekday() time.Weekday {
+	return t.time.Weekday()
+}
+
+// AddDays returns the date shifted by the number of days.
+func (t DateOnly) AddDays(days int) DateOnly {
+	return DateOnly{time: t.ToTime(time.UTC).AddDate(0, 0, days)}
+}
+
+// AddMonths returns the date shifted by the number of months, the day is clamped to the end of the month, e.g. January 31st plus a month is the end of February.
+func (t DateOnly) AddMonths(months int) DateOnly {
+	return DateOnly{time: addMonths(t.ToTime(time.UTC), months)}
+}
+
+// AddYears returns the date shifted by the number of years, February 29th is clamped to February 28th on non leap years.
+func (t DateOnly) AddYears(years int) DateOnly {
+	return t.AddMonths(years * 12)
+}
+
+// DaysUntil returns the number of days from the date to the other date, which is negative when the other date is before.
+func (t DateOnly) DaysUntil(other DateOnly) int {
+	return int(other.ToTime(time.UTC).Sub(t.ToTime(time.UTC)) / (24 * time.Hour))
+}
+
+// Compare returns -1, 0 or 1 when the date is respectively before, equal or after the other date.
+func (t DateOnly) Compare(other DateOnly) int {
+	return t.ToTime(time.UTC).Compare(other.ToTime(time.UTC))
+}
+
+// Before returns whether the date is before the other date.
+func (t DateOnly) Before(other DateOnly) bool {
+	return t.Compare(other) < 0
+}
+
+// After returns whether the date is after the other date.
+func (t DateOnly) After(other DateOnly) bool {
+	return t.Compare(other) > 0
+}
+
+// Equal returns whether both values represent the same date, regardless of the time and location of the underlying time.
+func (t DateOnly) Equal(other DateOnly) bool {
+	return t.Compare(other) == 0
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t DateOnly) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, an empty text results in the zero value.
+func (t *DateOnly) UnmarshalText(text []byte) error {
+	value, err := ParseDateOnly(string(text))
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		*t = DateOnly{}
+	} else {
+		*t = *value
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t DateOnly) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, null leaves the value unchanged.
+func (t *DateOnly) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, t)
+}
+
+// Value implements driver.Valuer, the date is stored as text.
+func (t DateOnly) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+// Scan implements sql.Scanner, it accepts text and time values, null results in the zero value.
+func (t *DateOnly) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*t = DateOnly{}
+		return nil
+	case time.Time:
+		*t = DateOnly{time: value}
+		return nil
+	}
+	return scanText(src, "DateOnly", t)
+}
+
+// Set implements flag.Value.
+func (t *DateOnly) Set(value string) error {
+	return t.UnmarshalText([]byte(value))
+}
+
+// unmarshalJSONString unmarshals a JSON string through the text unmarshaler of the target, null leaves the target unchanged.
+func unmarshalJSONString(data []byte, target interface{ UnmarshalText([]byte) error }) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return target.UnmarshalText([]byte(value))
+}
+
+// scanText scans a text database value through the text unmarshaler of the target.
+func scanText(src any, typeName string, target interface{ UnmarshalText([]byte) error }) error {
+	switch value := src.(type) {
+	case string:
+		return target.UnmarshalText([]byte(value))
+	case []byte:
+		return target.UnmarshalText(value)
+	}
+	return fmt.Errorf("cannot scan a value of type %T into a %s", src, typeName)
+}
@@ -1,9 +1,25 @@
 package serialization
 
 import (
-	assert "github.com/stretchr/testify/assert"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"flag"
 	"testing"
 	"time"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+var (
+	_ encoding.TextMarshaler   = DateOnly{}
+	_ encoding.TextUnmarshaler = &DateOnly{}
+	_ json.Marshaler           = DateOnly{}
+	_ json.Unmarshaler         = &DateOnly{}
+	_ driver.Valuer            = DateOnly{}
+	_ sql.Scanner              = &DateOnly{}
+	_ flag.Value               = &DateOnly{}
 )
 
 func TestItParsesADateOnly(t *testing.T) {
@@ -21,3 +37,82 @@ func TestItCreateANewDateOnly(t *testing.T) {
 	dateOnly := NewDateOnly(time.Date(2020, 1, 4, 0, 0, 0, 0, time.UTC))
 	assert.Equal(t, "2020-01-04", dateOnly.String())
 }
+
+func TestDateOnlyArithmetic(t *testing.T) {
+	dateOnly, _ := ParseDateOnly("2024-01-31")
+
+	assert.Equal(t, "2024-02-01", dateOnly.AddDays(1).String())
+	assert.Equal(t, "2023-12-31", dateOnly.AddDays(-31).String())
+	assert.Equal(t, "2024-02-29", dateOnly.AddMonths(1).String())
+	assert.Equal(t, "2025-01-31", dateOnly.AddYears(1).String())
+	assert.Equal(t, "2025-02-28", dateOnly.AddDays(29).AddYears(1).String())
+	assert.Equal(t, 366, dateOnly.DaysUntil(dateOnly.AddYears(1)))
+	assert.Equal(t, -1, dateOnly.DaysUntil(dateOnly.AddDays(-1)))
+	assert.Equal(t, time.Wednesday, dateOnly.Weekday())
+}
+
+func TestDateOnlyComparison(t *testing.T) {
+	first := NewDateOnly(time.Date(2024, 1, 4, 23, 0, 0, 0, time.UTC))
+	second, _ := ParseDateOnly("2024-01-05")
+
+	assert.True(t, first.Before(*second))
+	assert.False(t, first.After(*second))
+	assert.True(t, second.After(*first))
+	assert.Equal(t, -1, first.Compare(*second))
+	assert.True(t, first.Equal(*NewDateOnly(time.Date(2024, 1, 4, 1, 0, 0, 0, time.UTC))))
+}
+
+func TestDateOnlyToTime(t *testing.T) {
+	location := time.FixedZone("UTC+2", 2*60*60)
+	dateOnly := NewDateOnly(time.Date(2024, 1, 4, 15, 4, 5, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2024, 1, 4, 0, 0, 0, 0, location), dateOnly.ToTime(location))
+	assert.Equal(t, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), dateOnly.ToTime(nil))
+}
+
+func TestDateOnlyJson(t *testing.T) {
+	type model struct {
+		Date     DateOnly  `json:"date"`
+		Optional *DateOnly `json:"optional"`
+	}
+	value := model{Date: *NewDateOnly(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC))}
+
+	content, err := json.Marshal(value)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"date":"2024-01-04","optional":null}`, string(content))
+
+	var result model
+	assert.Nil(t, json.Unmarshal([]byte(`{"date":"2024-01-05","optional":"2024-01-06"}`), &result))
+	assert.Equal(t, "2024-01-05", result.Date.String())
+	assert.Equal(t, "2024-01-06", result.Optional.String())
+	assert.NotNil(t, json.Unmarshal([]byte(`{"date":"2024-01-05T00:00:00Z"}`), &result))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"date":20240105}`), &result))
+}
+
+func TestDateOnlySql(t *testing.T) {
+	dateOnly, _ := ParseDateOnly("2024-01-04")
+	value, err := dateOnly.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "2024-01-04", value)
+
+	var result DateOnly
+	assert.Nil(t, result.Scan("2024-01-05"))
+	assert.Equal(t, "2024-01-05", result.String())
+	assert.Nil(t, result.Scan([]byte("2024-01-06")))
+	assert.Equal(t, "2024-01-06", result.String())
+	assert.Nil(t, result.Scan(time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2024-01-07", result.String())
+	assert.Nil(t, result.Scan(nil))
+	assert.Equal(t, DateOnly{}, result)
+	assert.EqualError(t, result.Scan(int64(1)), "cannot scan a value of type int64 into a DateOnly")
+}
+
+func TestDateOnlyFlag(t *testing.T) {
+	var dateOnly DateOnly
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Var(&dateOnly, "date", "a date")
+
+	assert.Nil(t, flags.Parse([]string{"-date", "2024-01-04"}))
+	assert.Equal(t, "2024-01-04", dateOnly.String())
+	assert.NotNil(t, dateOnly.Set("yesterday"))
+}
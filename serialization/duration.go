@@ -1,4 +1,4 @@
-// duration provides a partial implementation of ISO8601 durations. (no months)
+// duration provides an implementation of ISO8601 durations, including signs, months, weeks and fractions.
 
 package serialization
 
@@ -6,9 +6,10 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"math"
+	"math/big"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,17 +17,29 @@ var (
 	// errBadFormat is returned when parsing fails
 	errBadFormat = errors.New("bad format string")
 
-	errWeeksNotWithYearsOrMonth = errors.New("weeks are not allowed with years or months")
+	errFractionNotLast = errors.New("only the smallest component of a duration can have a fraction")
 
-	errWeeksNotAlone = errors.New("weeks are not allowed with other duration components")
+	errMixedSigns = errors.New("the calendar (years and months) and time components of a duration cannot have different signs")
 
-	errMonthsInDurationUseOverload = errors.New("months are not allowed with the ToDuration method, use the overload instead")
+	errMonthsInToDuration = errors.New("months are not allowed with the ToDuration method, use ToDurationFrom(start) instead")
 
-	full = regexp.MustCompile(`P((?P<year>\d+)Y)?((?P<month>\d+)M)?((?P<day>\d+)D)?(T((?P<hour>\d+)H)?((?P<minute>\d+)M)?((?P<second>\d+(?:\.\d+))S)?)?`)
-	week = regexp.MustCompile(`P((?P<week>\d+)W)`)
+	full = regexp.MustCompile(`^(?P<sign>[-+])?P((?P<year>\d+(?:[.,]\d+)?)Y)?((?P<month>\d+(?:[.,]\d+)?)M)?((?P<week>\d+(?:[.,]\d+)?)W)?((?P<day>\d+(?:[.,]\d+)?)D)?(?P<time>T((?P<hour>\d+(?:[.,]\d+)?)H)?((?P<minute>\d+(?:[.,]\d+)?)M)?((?P<second>\d+(?:[.,]\d+)?)S)?)?$`)
+)
+
+const (
+	msToS     = 1000
+	sToMi     = 60
+	miToH     = 60
+	hToD      = 24
+	dToW      = 7
+	moToY     = 12
+	dToMo     = 30
+	msPerDay  = msToS * sToMi * miToH * hToD
+	msPerWeek = msPerDay * dToW
 )
 
 type duration struct {
+	Negative     bool
 	Years        int
 	Months       int
 	Weeks        int
@@ -37,71 +50,117 @@ type duration struct {
 	MilliSeconds int
 }
 
+// durationUnits lists the components of a duration from the largest to the smallest with the factor converting a fraction of the unit into the next one.
+// Fractions of months are converted assuming 30 days per month.
+var durationUnits = []struct {
+	name   string
+	factor int64
+}{
+	{"year", moToY},
+	{"month", dToMo},
+	{"week", dToW},
+	{"day", hToD},
+	{"hour", miToH},
+	{"minute", sToMi},
+	{"second", msToS},
+}
+
 func durationFromString(dur string) (*duration, error) {
-	var (
-		match []string
-		re    *regexp.Regexp
-	)
-
-	if week.MatchString(dur) {
-		match = week.FindStringSubmatch(dur)
-		re = week
-	} else if full.MatchString(dur) {
-		match = full.FindStringSubmatch(dur)
-		re = full
-	} else {
+	match := full.FindStringSubmatch(strings.TrimSpace(dur))
+	if match == nil {
+		return nil, errBadFormat
+	}
+	groups := make(map[string]string)
+	for i, name := range full.SubexpNames() {
+		if name != "" {
+			groups[name] = match[i]
+		}
+	}
+	if groups["time"] == "T" {
 		return nil, errBadFormat
 	}
 
-	d := &duration{}
-
-	for i, name := range re.SubexpNames() {
-		part := match[i]
-		if i == 0 || name == "" || part == "" {
+	// the last value holds the milliseconds
+	values := make([]int64, len(durationUnits)+1)
+	found := false
+	for i, unit := range durationUnits {
+		part := groups[unit.name]
+		if part == "" {
 			continue
 		}
-
-		val, err := strconv.ParseFloat(part, 64)
-		if err != nil {
-			return nil, err
+		value, ok := new(big.Rat).SetString(strings.Replace(part, ",", ".", 1))
+		if !ok {
+			return nil, errBadFormat
+		}
+		if !value.IsInt() {
+			for _, smaller := range durationUnits[i+1:] {
+				if groups[smaller.name] != "" {
+					return nil, errFractionNotLast
+				}
+			}
 		}
-		switch name {
-		case "year":
-			d.Years = int(val)
-		case "month":
-			d.Months = int(val)
-		case "week":
-			d.Weeks = int(val)
-		case "day":
-			d.Days = int(val)
-		case "hour":
-			c := time.Duration(val) * time.Hour
-			d.Hours = int(c.Hours())
-		case "minute":
-			c := time.Duration(val) * time.Minute
-			d.Minutes = int(c.Minutes())
-		case "second":
-			s, milli := math.Modf(val)
-			d.Seconds = int(s)
-			d.MilliSeconds = int(milli * 1000)
-		default:
-			return nil, fmt.Errorf("unknown field %s", name)
+		found = true
+		if err := cascadeDurationValue(values, i, value); err != nil {
+			return nil, fmt.Errorf("the %s component overflows", unit.name)
 		}
 	}
+	if !found {
+		return nil, errBadFormat
+	}
+
+	return &duration{
+		Negative:     groups["sign"] == "-",
+		Years:        int(values[0]),
+		Months:       int(values[1]),
+		Weeks:        int(values[2]),
+		Days:         int(values[3]),
+		Hours:        int(values[4]),
+		Minutes:      int(values[5]),
+		Seconds:      int(values[6]),
+		MilliSeconds: int(values[7]),
+	}, nil
+}
 
-	return d, nil
+// cascadeDurationValue adds the value to the component at index and carries its fraction over to the smaller components.
+// Sub millisecond precision is rounded to the nearest millisecond.
+func cascadeDurationValue(values []int64, index int, value *big.Rat) error {
+	remaining := new(big.Rat).Set(value)
+	for i := index; i < len(values); {
+		if i == len(values)-1 {
+			remaining.Add(remaining, big.NewRat(1, 2))
+		}
+		whole := new(big.Int).Quo(remaining.Num(), remaining.Denom())
+		if !whole.IsInt64() {
+			return errBadFormat
+		}
+		values[i] += whole.Int64()
+		remaining.Sub(remaining, new(big.Rat).SetInt(whole))
+		if remaining.Sign() == 0 || i == len(values)-1 {
+			return nil
+		}
+		remaining.Mul(remaining, big.NewRat(durationUnits[i].factor, 1))
+		if durationUnits[i].name == "month" {
+			// fractions of months are carried over to days
+			i += 2
+		} else {
+			i++
+		}
+	}
+	return nil
 }
 
 // String prints out the value passed in.
 func (d *duration) string() string {
 	var s bytes.Buffer
 
-	err := d.normalize()
-
-	if err != nil {
-		panic(err)
+	if err := d.normalize(); err != nil {
+		// durations mixing signs cannot be represented, the components are written with their own sign
+		return d.rawString()
 	}
 
+	if d.Negative {
+		s.WriteString("-")
+	}
 	s.WriteString("P")
 	if d.Years > 0 {
 		s.WriteString(fmt.Sprintf("%dY", d.Years))
@@ -123,58 +182,109 @@ func (d *duration) string() string {
 		if d.Minutes > 0 {
 			s.WriteString(fmt.Sprintf("%dM", d.Minutes))
 		}
-		if d.Seconds > 0 {
-			s.WriteString(fmt.Sprintf("%dS", d.Seconds))
+		if d.Seconds > 0 || d.MilliSeconds > 0 {
+			s.WriteString(formatSeconds(d.Seconds, d.MilliSeconds))
+			s.WriteString("S")
 		}
 	}
+	if s.Len() == len("P") || (d.Negative && s.Len() == len("-P")) {
+		return "PT0S"
+	}
 
 	return s.String()
 }
 
+// rawString writes the components without normalizing them.
+func (d *duration) rawString() string {
+	sign := ""
+	if d.Negative {
+		sign = "-"
+	}
+	return fmt.Sprintf("%sP%dY%dM%dW%dDT%dH%dM%sS", sign, d.Years, d.Months, d.Weeks, d.Days, d.Hours, d.Minutes, formatSeconds(d.Seconds, d.MilliSeconds))
+}
+
+// text returns the ISO 8601 representation of the duration, durations whose calendar and time components have different signs
+// cannot be represented and return an error.
+func (d duration) text() (string, error) {
+	if err := d.normalize(); err != nil {
+		return "", err
+	}
+	return d.string(), nil
+}
+
+// formatSeconds writes the seconds and milliseconds as a single decimal number with the sign in front.
+func formatSeconds(seconds int, milliSeconds int) string {
+	total := int64(seconds)*msToS + int64(milliSeconds)
+	sign := ""
+	if total < 0 {
+		sign = "-"
+		total = -total
+	}
+	if total%msToS == 0 {
+		return sign + strconv.FormatInt(total/msToS, 10)
+	}
+	return sign + strings.TrimRight(fmt.Sprintf("%d.%03d", total/msToS, total%msToS), "0")
+}
+
+// calendarMonths returns the signed number of months of the calendar components.
+func (d *duration) calendarMonths() int64 {
+	months := int64(d.Years)*moToY + int64(d.Months)
+	if d.Negative {
+		return -months
+	}
+	return months
+}
+
+// fixedMilliSeconds returns the signed number of milliseconds of the weeks, days and time components, a day is 24 hours.
+func (d *duration) fixedMilliSeconds() int64 {
+	total := int64(d.Weeks)*msPerWeek +
+		int64(d.Days)*msPerDay +
+		int64(d.Hours)*msToS*sToMi*miToH +
+		int64(d.Minutes)*msToS*sToMi +
+		int64(d.Seconds)*msToS +
+		int64(d.MilliSeconds)
+	if d.Negative {
+		return -total
+	}
+	return total
+}
+
 // Normalize makes sure that all fields are represented as the smallest meaningful value possible by dividing them out by the conversion factor to the larger unit.
-// e.g. if you have a duration of 14 days, 25 hours, and 61 minutes, it will be normalized to 2 weeks, 2 hours, and 1 minute.
+// e.g. if you have a duration of 13 days, 25 hours, and 61 minutes, it will be normalized to 14 days, 2 hours, and 1 minute.
 // this function does not normalize days to months, weeks to months or weeks to years as they do not always convert with the same value.
-// it also won't normalize days to weeks if months or years are present, or if days are not an exact multiple of 7,
-// since ISO 8601 requires the week designator to be used alone (no other date or time components).
-// it will return an error if the value is invalid.
+// weeks combined with other components are converted to days and days are only converted to weeks when they are an exact multiple of 7
+// and no other component is present, since ISO 8601 requires the week designator to be used alone.
+// components with different signs are combined and the sign of the duration is set accordingly,
+// it will return an error if the calendar and time components have different signs.
 func (d *duration) normalize() error {
-	msToS := 1000
-	StoM := 60
-	MtoH := 60
-	HtoD := 24
-	DtoW := 7
-	MtoY := 12
-	if d.MilliSeconds >= msToS {
-		d.Seconds += d.MilliSeconds / msToS
-		d.MilliSeconds %= msToS
-	}
-	if d.Seconds >= StoM {
-		d.Minutes += d.Seconds / StoM
-		d.Seconds %= StoM
-	}
-	if d.Minutes >= MtoH {
-		d.Hours += d.Minutes / MtoH
-		d.Minutes %= MtoH
-	}
-	if d.Hours >= HtoD {
-		d.Days += d.Hours / HtoD
-		d.Hours %= HtoD
-	}
-	if d.Days >= DtoW && d.Days%DtoW == 0 && d.Months == 0 && d.Years == 0 {
-		d.Weeks += d.Days / DtoW
-		d.Days = 0
+	months := d.calendarMonths()
+	fixed := d.fixedMilliSeconds()
+	if (months < 0 && fixed > 0) || (months > 0 && fixed < 0) {
+		return errMixedSigns
 	}
-	if d.Months > MtoY {
-		d.Years += d.Months / MtoY
-		d.Months %= MtoY
+	d.Negative = months < 0 || fixed < 0
+	if months < 0 {
+		months = -months
 	}
-
-	if d.Weeks != 0 && (d.Years != 0 || d.Months != 0) {
-		return errWeeksNotWithYearsOrMonth
+	if fixed < 0 {
+		fixed = -fixed
 	}
 
-	if d.Weeks != 0 && (d.Days != 0 || d.Hours != 0 || d.Minutes != 0 || d.Seconds != 0 || d.MilliSeconds != 0) {
-		return errWeeksNotAlone
+	d.Years = int(months / moToY)
+	d.Months = int(months % moToY)
+
+	d.Weeks = 0
+	d.MilliSeconds = int(fixed % msToS)
+	fixed /= msToS
+	d.Seconds = int(fixed % sToMi)
+	fixed /= sToMi
+	d.Minutes = int(fixed % miToH)
+	fixed /= miToH
+	d.Hours = int(fixed % hToD)
+	d.Days = int(fixed / hToD)
+	if d.Days >= dToW && d.Days%dToW == 0 && d.Months == 0 && d.Years == 0 && !d.hasTimePart() {
+		d.Weeks = d.Days / dToW
+		d.Days = 0
 	}
 
 	return nil
@@ -184,16 +294,17 @@ func (d *duration) normalize() error {
 }
 
 func (d *duration) hasTimePart() bool {
-	return d.Hours != 0 || d.Minutes != 0 || d.Seconds != 0
+	return d.Hours != 0 || d.Minutes != 0 || d.Seconds != 0 || d.MilliSeconds != 0
 }
 
 func (d *duration) toDuration() (time.Duration, error) {
 	if d.Months != 0 {
-		return 0, errMonthsInDurationUseOverload
+		return 0, errMonthsInToDuration
 	}
 	return d.toDurationWithMonths(31)
 }
 
+// toDurationWithMonths returns the duration counting a month as the given number of days and a year as 365 days.
 func (d *duration) toDurationWithMonths(daysInAMonth int) (time.Duration, error) {
 	day := time.Hour * 24
 	year := day * 365
@@ -213,6 +324,10 @@ func (d *duration) toDurationWithMonths(daysInAMonth int) (time.Duration, error)
 	tot += time.Hour * time.Duration(d.Hours)
 	tot += time.Minute * time.Duration(d.Minutes)
 	tot += time.Second * time.Duration(d.Seconds)
+	tot += time.Millisecond * time.Duration(d.MilliSeconds)
 
+	if d.Negative {
+		tot = -tot
+	}
 	return tot, nil
 }
@@ -19,7 +19,7 @@ func TestItNormalizesMS(t *testing.T) {
 	// Assert
 	assert.Equal(t, 1, duration.Seconds)
 	assert.Equal(t, 1, duration.MilliSeconds)
-	assert.Equal(t, "PT1S", result)
+	assert.Equal(t, "PT1.001S", result)
 }
 
 func TestItNormalizesS(t *testing.T) {
@@ -112,7 +112,7 @@ func TestItNormalizes7DaysToWeek(t *testing.T) {
 	assert.Equal(t, "P1W", result)
 }
 
-func TestItRefusesWeeksWithDays(t *testing.T) {
+func TestItConvertsWeeksWithDaysToDays(t *testing.T) {
 	// Arrange: weeks cannot coexist with days per ISO 8601
 	duration := &duration{
 		Weeks: 1,
@@ -120,13 +120,15 @@ func TestItRefusesWeeksWithDays(t *testing.T) {
 	}
 
 	// Act
-	result := duration.normalize()
+	result := duration.string()
 
 	// Assert
-	assert.Equal(t, errWeeksNotAlone, result)
+	assert.Equal(t, 0, duration.Weeks)
+	assert.Equal(t, 8, duration.Days)
+	assert.Equal(t, "P8D", result)
 }
 
-func TestItRefusesWeeksWithHours(t *testing.T) {
+func TestItConvertsWeeksWithHoursToDays(t *testing.T) {
 	// Arrange: weeks cannot coexist with time components per ISO 8601
 	duration := &duration{
 		Weeks: 1,
@@ -134,10 +136,12 @@ func TestItRefusesWeeksWithHours(t *testing.T) {
 	}
 
 	// Act
-	result := duration.normalize()
+	result := duration.string()
 
 	// Assert
-	assert.Equal(t, errWeeksNotAlone, result)
+	assert.Equal(t, 0, duration.Weeks)
+	assert.Equal(t, 7, duration.Days)
+	assert.Equal(t, "P7DT2H", result)
 }
 
 func TestItDoesntNormalizesW(t *testing.T) {
@@ -187,7 +191,7 @@ func TestItNormalizesMo(t *testing.T) {
 	assert.Equal(t, "P1Y1M", result)
 }
 
-func TestItRefusesMoAndW(t *testing.T) {
+func TestItConvertsWeeksWithMonthsToDays(t *testing.T) {
 	// Arrange
 	duration := &duration{
 		Months: 13,
@@ -195,24 +199,53 @@ func TestItRefusesMoAndW(t *testing.T) {
 	}
 
 	// Act
-	result := duration.normalize()
+	result := duration.string()
 
 	// Assert
-	assert.Equal(t, errWeeksNotWithYearsOrMonth, result)
+	assert.Equal(t, "P1Y1M70D", result)
 }
 
-func TestItRefusesYAndW(t *testing.T) {
+func TestItConvertsWeeksWithYearsToDays(t *testing.T) {
 	// Arrange
 	duration := &duration{
 		Years: 13,
 		Weeks: 10,
 	}
 
+	// Act
+	result := duration.string()
+
+	// Assert
+	assert.Equal(t, "P13Y70D", result)
+}
+
+func TestItNormalizesMixedSigns(t *testing.T) {
+	// Arrange
+	duration := &duration{
+		Days:  1,
+		Hours: -25,
+	}
+
+	// Act
+	result := duration.string()
+
+	// Assert
+	assert.True(t, duration.Negative)
+	assert.Equal(t, "-PT1H", result)
+}
+
+func TestItRefusesCalendarAndTimeWithDifferentSigns(t *testing.T) {
+	// Arrange
+	duration := &duration{
+		Months: 1,
+		Days:   -1,
+	}
+
 	// Act
 	result := duration.normalize()
 
 	// Assert
-	assert.Equal(t, errWeeksNotWithYearsOrMonth, result)
+	assert.Equal(t, errMixedSigns, result)
 }
 
 func TestItFailsMoToDuration(t *testing.T) {
@@ -227,7 +260,7 @@ func TestItFailsMoToDuration(t *testing.T) {
 
 	// Assert
 	assert.Equal(t, time.Duration(0), result)
-	assert.Equal(t, errMonthsInDurationUseOverload, err)
+	assert.Equal(t, errMonthsInToDuration, err)
 }
 
 func TestItParsesMonth(t *testing.T) {
@@ -268,3 +301,68 @@ func TestToDurationWithMonths(t *testing.T) {
 	expectedResult := time.Hour*24*365 + time.Hour*24*30*2 + time.Hour*24*4 + time.Hour*5 + time.Minute*6 + time.Second*7
 	assert.Equal(t, expectedResult, result)
 }
+
+func TestItParsesSignedDurations(t *testing.T) {
+	// Act
+	duration, err := durationFromString("-P1DT2H")
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, duration.Negative)
+	assert.Equal(t, 1, duration.Days)
+	assert.Equal(t, 2, duration.Hours)
+	assert.Equal(t, "-P1DT2H", duration.string())
+}
+
+func TestItParsesFractions(t *testing.T) {
+	cases := map[string]string{
+		"PT1.5H":   "PT1H30M",
+		"P0.5D":    "PT12H",
+		"P1.5Y":    "P1Y6M",
+		"P0,5W":    "P3DT12H",
+		"PT0.001S": "PT0.001S",
+		"PT1.25S":  "PT1.25S",
+		"P0.5M":    "P15D",
+	}
+	for input, expected := range cases {
+		duration, err := durationFromString(input)
+		assert.Nil(t, err, input)
+		assert.Equal(t, expected, duration.string(), input)
+	}
+}
+
+func TestItParsesCombinedWeeks(t *testing.T) {
+	// Act
+	duration, err := durationFromString("P1W2DT3H")
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P9DT3H", duration.string())
+}
+
+func TestItRefusesInvalidDurations(t *testing.T) {
+	for _, input := range []string{"", "P", "PT", "1D", "P1.5DT1H", "P1H", "PT1D", "P-1D"} {
+		_, err := durationFromString(input)
+		assert.NotNil(t, err, input)
+	}
+}
+
+func TestItWritesZeroDurations(t *testing.T) {
+	assert.Equal(t, "PT0S", (&duration{}).string())
+}
+
+func TestToDurationKeepsSignAndMilliseconds(t *testing.T) {
+	// Arrange
+	duration := &duration{
+		Negative:     true,
+		Seconds:      1,
+		MilliSeconds: 500,
+	}
+
+	// Act
+	result, err := duration.toDuration()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, -1500*time.Millisecond, result)
+}
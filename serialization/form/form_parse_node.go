@@ -0,0 +1,375 @@
+package form
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/microsoft/kiota-abstractions-go/serialization/internal/parsing"
+)
+
+// FormParseNode is a ParseNode implementation for URL form encoded content.
+// Form encoding only supports a single level of fields, nested objects return an error.
+// Collections are read from repeated key=value pairs, values containing commas are never split.
+type FormParseNode struct {
+	fields                    map[string][]string
+	values                    []string
+	key                       string
+	onBeforeAssignFieldValues absser.ParsableAction
+	onAfterAssignFieldValues  absser.ParsableAction
+}
+
+const nullValue = "null"
+
+// NewFormParseNode creates a new FormParseNode from the URL form encoded content.
+func NewFormParseNode(content []byte) (*FormParseNode, error) {
+	if content == nil {
+		return nil, errors.New("content cannot be nil")
+	}
+	fields, err := url.ParseQuery(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, err
+	}
+	return &FormParseNode{fields: fields}, nil
+}
+
+func (n *FormParseNode) isRoot() bool {
+	return n.fields != nil
+}
+
+// rawValue returns the single value of the node, a repeated key holds a collection and cannot be read as a single value.
+func (n *FormParseNode) rawValue() (*string, error) {
+	if n.isRoot() || len(n.values) == 0 {
+		return nil, nil
+	}
+	if len(n.values) > 1 {
+		return nil, fmt.Errorf("%q is repeated %d times, it can only be read as a collection", n.key, len(n.values))
+	}
+	if n.values[0] == nullValue {
+		return nil, nil
+	}
+	value := n.values[0]
+	return &value, nil
+}
+
+// collectionValues returns the values of a collection node, collections are written as one key=value pair per item.
+func (n *FormParseNode) collectionValues() []string {
+	if n.isRoot() || len(n.values) == 0 {
+		return nil
+	}
+	if len(n.values) == 1 && n.values[0] == nullValue {
+		return nil
+	}
+	return n.values
+}
+
+func parseValue[T any](n *FormParseNode, parse func(string) (T, error)) (*T, error) {
+	value, err := n.rawValue()
+	if value == nil || err != nil {
+		return nil, err
+	}
+	result, err := parse(*value)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the value of %q: %w", n.key, err)
+	}
+	return &result, nil
+}
+
+// GetChildNode returns a new parse node for the given identifier.
+func (n *FormParseNode) GetChildNode(index string) (absser.ParseNode, error) {
+	if index == "" {
+		return nil, errors.New("index is empty")
+	}
+	if !n.isRoot() {
+		return nil, fmt.Errorf("form content does not support nested objects, %q cannot have children", n.key)
+	}
+	values, ok := n.fields[index]
+	if !ok {
+		return nil, nil
+	}
+	return n.newChildNode(index, values), nil
+}
+
+func (n *FormParseNode) newChildNode(key string, values []string) *FormParseNode {
+	return &FormParseNode{
+		values:                    values,
+		key:                       key,
+		onBeforeAssignFieldValues: n.onBeforeAssignFieldValues,
+		onAfterAssignFieldValues:  n.onAfterAssignFieldValues,
+	}
+}
+
+// GetObjectValue returns the Parsable value from the node.
+// Only the root node can be deserialized into an object.
+func (n *FormParseNode) GetObjectValue(ctor absser.ParsableFactory) (absser.Parsable, error) {
+	if ctor == nil {
+		return nil, errors.New("constructor is nil")
+	}
+	if !n.isRoot() {
+		return nil, fmt.Errorf("form content does not support nested objects, %q cannot be deserialized as an object", n.key)
+	}
+	result, err := ctor(n)
+	if err != nil {
+		return nil, err
+	}
+	if isNil(result) {
+		return nil, errors.New("the constructor returned a nil value")
+	}
+	if n.onBeforeAssignFieldValues != nil {
+		if err := n.onBeforeAssignFieldValues(result); err != nil {
+			return nil, err
+		}
+	}
+	fields := result.GetFieldDeserializers()
+	var additionalData map[string]interface{}
+	if holder, ok := result.(absser.AdditionalDataHolder); ok {
+		additionalData = holder.GetAdditionalData()
+		if additionalData == nil {
+			additionalData = make(map[string]interface{})
+			holder.SetAdditionalData(additionalData)
+		}
+	}
+	for key, values := range n.fields {
+		field := fields[key]
+		if field == nil {
+			if additionalData != nil {
+				additionalData[key] = additionalDataValue(values)
+			}
+			continue
+		}
+		if err := field(n.newChildNode(key, values)); err != nil {
+			return nil, err
+		}
+	}
+	if n.onAfterAssignFieldValues != nil {
+		if err := n.onAfterAssignFieldValues(result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// additionalDataValue returns the value of a field missing from the model, the values of a repeated key are returned as a slice.
+func additionalDataValue(values []string) interface{} {
+	if len(values) > 1 {
+		return slices.Clone(values)
+	}
+	if len(values) == 0 || values[0] == nullValue {
+		return nil
+	}
+	return values[0]
+}
+
+// GetCollectionOfObjectValues returns an error as form content does not support collections of objects.
+func (n *FormParseNode) GetCollectionOfObjectValues(ctor absser.ParsableFactory) ([]absser.Parsable, error) {
+	return nil, fmt.Errorf("form content does not support collections of objects, %q cannot be deserialized as a collection of objects", n.key)
+}
+
+// GetCollectionOfPrimitiveValues returns the collection of primitive values from the node.
+func (n *FormParseNode) GetCollectionOfPrimitiveValues(targetType string) ([]interface{}, error) {
+	if targetType == "" {
+		return nil, errors.New("targetType is empty")
+	}
+	values := n.collectionValues()
+	if values == nil {
+		return nil, nil
+	}
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		node := n.newChildNode(n.key, []string{value})
+		var val interface{}
+		var err error
+		switch targetType {
+		case "string":
+			val, err = node.GetStringValue()
+		case "bool":
+			val, err = node.GetBoolValue()
+		case "uint8":
+			val, err = node.GetInt8Value()
+		case "byte":
+			val, err = node.GetByteValue()
+		case "float32":
+			val, err = node.GetFloat32Value()
+		case "float64":
+			val, err = node.GetFloat64Value()
+		case "int32":
+			val, err = node.GetInt32Value()
+		case "int64":
+			val, err = node.GetInt64Value()
+		case "time":
+			val, err = node.GetTimeValue()
+		case "timeonly":
+			val, err = node.GetTimeOnlyValue()
+		case "dateonly":
+			val, err = node.GetDateOnlyValue()
+		case "isoduration":
+			val, err = node.GetISODurationValue()
+		case "uuid":
+			val, err = node.GetUUIDValue()
+		case "base64":
+			val, err = node.GetByteArrayValue()
+		default:
+			return nil, fmt.Errorf("targetType %s is not supported", targetType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result[i] = val
+	}
+	return result, nil
+}
+
+// GetCollectionOfEnumValues returns the collection of Enum values from the node.
+func (n *FormParseNode) GetCollectionOfEnumValues(parser absser.EnumFactory) ([]interface{}, error) {
+	if parser == nil {
+		return nil, errors.New("parser is nil")
+	}
+	values := n.collectionValues()
+	if values == nil {
+		return nil, nil
+	}
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		val, err := parser(value)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = val
+	}
+	return result, nil
+}
+
+// GetStringValue returns a String value from the nodes.
+func (n *FormParseNode) GetStringValue() (*string, error) {
+	return n.rawValue()
+}
+
+// GetBoolValue returns a Bool value from the nodes.
+func (n *FormParseNode) GetBoolValue() (*bool, error) {
+	return parseValue(n, strconv.ParseBool)
+}
+
+// GetInt8Value returns a int8 value from the nodes.
+func (n *FormParseNode) GetInt8Value() (*int8, error) {
+	return parseValue(n, parsing.Int[int8](8))
+}
+
+// GetByteValue returns a Byte value from the nodes.
+func (n *FormParseNode) GetByteValue() (*byte, error) {
+	return parseValue(n, parsing.Byte)
+}
+
+// GetFloat32Value returns a Float32 value from the nodes.
+func (n *FormParseNode) GetFloat32Value() (*float32, error) {
+	return parseValue(n, parsing.Float[float32](32))
+}
+
+// GetFloat64Value returns a Float64 value from the nodes.
+func (n *FormParseNode) GetFloat64Value() (*float64, error) {
+	return parseValue(n, parsing.Float[float64](64))
+}
+
+// GetInt32Value returns a int32 value from the nodes.
+func (n *FormParseNode) GetInt32Value() (*int32, error) {
+	return parseValue(n, parsing.Int[int32](32))
+}
+
+// GetInt64Value returns a int64 value from the nodes.
+func (n *FormParseNode) GetInt64Value() (*int64, error) {
+	return parseValue(n, parsing.Int[int64](64))
+}
+
+// GetTimeValue returns a Time value from the nodes.
+func (n *FormParseNode) GetTimeValue() (*time.Time, error) {
+	return parseValue(n, parsing.Time)
+}
+
+// GetISODurationValue returns a ISODuration value from the nodes.
+func (n *FormParseNode) GetISODurationValue() (*absser.ISODuration, error) {
+	return parseValue(n, parsing.Dereference(absser.ParseISODuration))
+}
+
+// GetTimeOnlyValue returns a TimeOnly value from the nodes.
+func (n *FormParseNode) GetTimeOnlyValue() (*absser.TimeOnly, error) {
+	return parseValue(n, parsing.Dereference(absser.ParseTimeOnly))
+}
+
+// GetDateOnlyValue returns a DateOnly value from the nodes.
+func (n *FormParseNode) GetDateOnlyValue() (*absser.DateOnly, error) {
+	return parseValue(n, parsing.Dereference(absser.ParseDateOnly))
+}
+
+// GetUUIDValue returns a UUID value from the nodes.
+func (n *FormParseNode) GetUUIDValue() (*uuid.UUID, error) {
+	return parseValue(n, uuid.Parse)
+}
+
+// GetEnumValue returns a Enum value from the nodes.
+func (n *FormParseNode) GetEnumValue(parser absser.EnumFactory) (interface{}, error) {
+	if parser == nil {
+		return nil, errors.New("parser is nil")
+	}
+	value, err := n.rawValue()
+	if value == nil || err != nil {
+		return nil, err
+	}
+	return parser(*value)
+}
+
+// GetByteArrayValue returns a ByteArray value from the nodes.
+func (n *FormParseNode) GetByteArrayValue() ([]byte, error) {
+	value, err := n.rawValue()
+	if value == nil || err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(*value)
+}
+
+// GetRawValue returns the values of the node as an interface of any type.
+// The root node returns a map of the fields, other nodes return their string value or the slice of the values of a repeated key.
+func (n *FormParseNode) GetRawValue() (interface{}, error) {
+	if n.isRoot() {
+		result := make(map[string]interface{}, len(n.fields))
+		for key, values := range n.fields {
+			value, err := n.newChildNode(key, values).GetRawValue()
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+		}
+		return result, nil
+	}
+	if len(n.values) > 1 {
+		return slices.Clone(n.values), nil
+	}
+	return n.rawValue()
+}
+
+// GetOnBeforeAssignFieldValues returns a callback invoked before the node is deserialized.
+func (n *FormParseNode) GetOnBeforeAssignFieldValues() absser.ParsableAction {
+	return n.onBeforeAssignFieldValues
+}
+
+// SetOnBeforeAssignFieldValues sets a callback invoked before the node is deserialized.
+func (n *FormParseNode) SetOnBeforeAssignFieldValues(action absser.ParsableAction) error {
+	n.onBeforeAssignFieldValues = action
+	return nil
+}
+
+// GetOnAfterAssignFieldValues returns a callback invoked after the node is deserialized.
+func (n *FormParseNode) GetOnAfterAssignFieldValues() absser.ParsableAction {
+	return n.onAfterAssignFieldValues
+}
+
+// SetOnAfterAssignFieldValues sets a callback invoked after the node is deserialized.
+func (n *FormParseNode) SetOnAfterAssignFieldValues(action absser.ParsableAction) error {
+	n.onAfterAssignFieldValues = action
+	return nil
+}
@@ -0,0 +1,31 @@
+package form
+
+import (
+	"errors"
+
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+)
+
+// FormParseNodeFactory implements ParseNodeFactory for URL form encoded content.
+type FormParseNodeFactory struct {
+}
+
+// NewFormParseNodeFactory creates a new instance of the FormParseNodeFactory.
+func NewFormParseNodeFactory() *FormParseNodeFactory {
+	return &FormParseNodeFactory{}
+}
+
+// GetValidContentType returns the content type this factory's parse nodes can deserialize.
+func (f *FormParseNodeFactory) GetValidContentType() (string, error) {
+	return FormContentType, nil
+}
+
+// GetRootParseNode returns a new ParseNode instance that is the root of the content
+func (f *FormParseNodeFactory) GetRootParseNode(contentType string, content []byte) (absser.ParseNode, error) {
+	if contentType == "" {
+		return nil, errors.New("the content type is empty")
+	} else if mediaType(contentType) != FormContentType {
+		return nil, errors.New("the provided content type is not supported")
+	}
+	return NewFormParseNode(content)
+}
@@ -0,0 +1,169 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoft/kiota-abstractions-go/internal"
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormParseNodeHonoursInterface(t *testing.T) {
+	node, err := NewFormParseNode([]byte(""))
+	assert.Nil(t, err)
+	assert.Implements(t, (*absser.ParseNode)(nil), node)
+}
+
+func TestFormParseNodeReadsObject(t *testing.T) {
+	content := "name=John+Doe+%26+co&count=3&tags=a&tags=b+c&status=suspended&previousStatus=active&previousStatus=suspended&birthday=2000-05-06&startTime=08%3A30%3A00&duration=P1DT2H&createdAt=2024-01-02T03%3A04%3A05Z&extra=value"
+	node, err := NewFormParseNode([]byte(content))
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(createTestEntityFromDiscriminatorValue)
+	assert.Nil(t, err)
+	entity := result.(*testEntity)
+	assert.Equal(t, "John Doe & co", *entity.name)
+	assert.Equal(t, int32(3), *entity.count)
+	assert.Equal(t, []string{"a", "b c"}, entity.tags)
+	assert.Equal(t, internal.SUSPENDED, *entity.status)
+	assert.Equal(t, []internal.PersonStatus{internal.ACTIVE, internal.SUSPENDED}, entity.previousStatus)
+	assert.Equal(t, "2000-05-06", entity.birthday.String())
+	assert.Equal(t, "08:30:00", entity.startTime.String())
+	assert.Equal(t, "P1DT2H", entity.duration.String())
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), entity.createdAt.UTC())
+	assert.Equal(t, map[string]interface{}{"extra": "value"}, entity.additionalData)
+}
+
+func TestFormParseNodeReadsNullValue(t *testing.T) {
+	node, err := NewFormParseNode([]byte("name=null&count=null"))
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(createTestEntityFromDiscriminatorValue)
+	assert.Nil(t, err)
+	entity := result.(*testEntity)
+	assert.Nil(t, entity.name)
+	assert.Nil(t, entity.count)
+}
+
+func TestFormParseNodeReadsPrimitiveCollections(t *testing.T) {
+	node, err := NewFormParseNode([]byte("numbers=1&numbers=2&ids=1,3"))
+	assert.Nil(t, err)
+	child, err := node.GetChildNode("numbers")
+	assert.Nil(t, err)
+	values, err := child.GetCollectionOfPrimitiveValues("int64")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), *(values[0].(*int64)))
+	assert.Equal(t, int64(2), *(values[1].(*int64)))
+
+	// collections are only written as repeated pairs, commas are part of the values
+	child, err = node.GetChildNode("ids")
+	assert.Nil(t, err)
+	values, err = child.GetCollectionOfPrimitiveValues("string")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(values))
+	assert.Equal(t, "1,3", *(values[0].(*string)))
+	_, err = child.GetCollectionOfPrimitiveValues("int32")
+	assert.NotNil(t, err)
+
+	_, err = child.GetCollectionOfPrimitiveValues("unknown")
+	assert.NotNil(t, err)
+}
+
+func TestFormParseNodeReadsScalarsWithoutSplittingOrJoining(t *testing.T) {
+	node, err := NewFormParseNode([]byte("name=Doe%2C+John&count=1&count=2&extra=a&extra=b"))
+	assert.Nil(t, err)
+	child, err := node.GetChildNode("name")
+	assert.Nil(t, err)
+	name, err := child.GetStringValue()
+	assert.Nil(t, err)
+	assert.Equal(t, "Doe, John", *name)
+
+	child, err = node.GetChildNode("count")
+	assert.Nil(t, err)
+	_, err = child.GetInt32Value()
+	assert.NotNil(t, err)
+	raw, err := child.GetRawValue()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, raw)
+
+	_, err = node.GetObjectValue(createTestEntityFromDiscriminatorValue)
+	assert.NotNil(t, err)
+	node, err = NewFormParseNode([]byte("name=Doe%2C+John&extra=a&extra=b"))
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(createTestEntityFromDiscriminatorValue)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"extra": []string{"a", "b"}}, result.(*testEntity).additionalData)
+}
+
+func TestFormParseNodeRejectsNilModels(t *testing.T) {
+	node, err := NewFormParseNode([]byte("name=value"))
+	assert.Nil(t, err)
+	_, err = node.GetObjectValue(func(absser.ParseNode) (absser.Parsable, error) {
+		return nil, nil
+	})
+	assert.NotNil(t, err)
+	_, err = node.GetObjectValue(func(absser.ParseNode) (absser.Parsable, error) {
+		return (*testEntity)(nil), nil
+	})
+	assert.NotNil(t, err)
+}
+
+func TestFormParseNodeRejectsNestedObjects(t *testing.T) {
+	node, err := NewFormParseNode([]byte("nested=value"))
+	assert.Nil(t, err)
+	_, err = node.GetObjectValue(createTestEntityFromDiscriminatorValue)
+	assert.EqualError(t, err, `form content does not support nested objects, "nested" cannot be deserialized as an object`)
+
+	child, err := node.GetChildNode("nested")
+	assert.Nil(t, err)
+	_, err = child.GetCollectionOfObjectValues(createTestEntityFromDiscriminatorValue)
+	assert.EqualError(t, err, `form content does not support collections of objects, "nested" cannot be deserialized as a collection of objects`)
+	_, err = child.GetChildNode("inner")
+	assert.NotNil(t, err)
+}
+
+func TestFormParseNodeCallsHooks(t *testing.T) {
+	node, err := NewFormParseNode([]byte("name=value"))
+	assert.Nil(t, err)
+	calls := make([]string, 0)
+	_ = node.SetOnBeforeAssignFieldValues(func(absser.Parsable) error {
+		calls = append(calls, "before")
+		return nil
+	})
+	_ = node.SetOnAfterAssignFieldValues(func(absser.Parsable) error {
+		calls = append(calls, "after")
+		return nil
+	})
+	_, err = node.GetObjectValue(createTestEntityFromDiscriminatorValue)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"before", "after"}, calls)
+}
+
+func TestFormParseNodeFactory(t *testing.T) {
+	factory := NewFormParseNodeFactory()
+	contentType, err := factory.GetValidContentType()
+	assert.Nil(t, err)
+	assert.Equal(t, "application/x-www-form-urlencoded", contentType)
+
+	node, err := factory.GetRootParseNode(contentType, []byte("a=b"))
+	assert.Nil(t, err)
+	assert.NotNil(t, node)
+
+	_, err = factory.GetRootParseNode("application/json", []byte("a=b"))
+	assert.NotNil(t, err)
+}
+
+func TestFormRoundTripThroughDefaultRegistries(t *testing.T) {
+	absser.DefaultSerializationWriterFactoryInstance.ContentTypeAssociatedFactories[FormContentType] = NewFormSerializationWriterFactory()
+	absser.DefaultParseNodeFactoryInstance.ContentTypeAssociatedFactories[FormContentType] = NewFormParseNodeFactory()
+	defer delete(absser.DefaultSerializationWriterFactoryInstance.ContentTypeAssociatedFactories, FormContentType)
+	defer delete(absser.DefaultParseNodeFactoryInstance.ContentTypeAssociatedFactories, FormContentType)
+
+	name := "value"
+	content, err := absser.Serialize(FormContentType+";charset=utf-8", &testEntity{name: &name, tags: []string{"x", "y"}})
+	assert.Nil(t, err)
+	result, err := absser.Deserialize(FormContentType, content, createTestEntityFromDiscriminatorValue)
+	assert.Nil(t, err)
+	entity := result.(*testEntity)
+	assert.Equal(t, "value", *entity.name)
+	assert.Equal(t, []string{"x", "y"}, entity.tags)
+}
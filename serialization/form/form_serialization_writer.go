@@ -0,0 +1,475 @@
+package form
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+)
+
+// FormSerializationWriter implements SerializationWriter for URL form encoded content.
+// Form encoding cannot represent nested objects, writing one returns an error.
+type FormSerializationWriter struct {
+	builder                    strings.Builder
+	depth                      int
+	onBeforeAssignFieldValues  absser.ParsableAction
+	onAfterAssignFieldValues   absser.ParsableAction
+	onStartObjectSerialization absser.ParsableWriter
+}
+
+// NewFormSerializationWriter creates a new instance of the FormSerializationWriter.
+func NewFormSerializationWriter() *FormSerializationWriter {
+	return &FormSerializationWriter{}
+}
+
+func errNestedObject(key string) error {
+	if key == "" {
+		return errors.New("form serialization does not support nested objects")
+	}
+	return fmt.Errorf("form serialization does not support nested objects, the value of %q is an object", key)
+}
+
+func errObjectCollection(key string) error {
+	return fmt.Errorf("form serialization does not support collections of objects, the value of %q is a collection of objects", key)
+}
+
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer, reflect.Interface, reflect.Slice:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+func (w *FormSerializationWriter) writeValue(key string, value string) {
+	if w.builder.Len() > 0 {
+		w.builder.WriteByte('&')
+	}
+	if key != "" {
+		w.builder.WriteString(url.QueryEscape(key))
+		w.builder.WriteByte('=')
+	}
+	w.builder.WriteString(url.QueryEscape(value))
+}
+
+func writeCollection[T any](w *FormSerializationWriter, key string, collection []T, format func(T) string) error {
+	for _, item := range collection {
+		w.writeValue(key, format(item))
+	}
+	return nil
+}
+
+func writePointer[T any](w *FormSerializationWriter, key string, value *T, format func(T) string) error {
+	if value != nil {
+		w.writeValue(key, format(*value))
+	}
+	return nil
+}
+
+func formatInt[T int8 | int32 | int64 | int](value T) string {
+	return strconv.FormatInt(int64(value), 10)
+}
+
+func formatFloat32(value float32) string {
+	return strconv.FormatFloat(float64(value), 'f', -1, 32)
+}
+
+func formatFloat64(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatByte(value byte) string {
+	return strconv.FormatUint(uint64(value), 10)
+}
+
+func formatTime(value time.Time) string {
+	return value.Format(time.RFC3339Nano)
+}
+
+func formatStringer[T fmt.Stringer](value T) string {
+	return value.String()
+}
+
+// WriteStringValue writes a String value to underlying the byte array.
+func (w *FormSerializationWriter) WriteStringValue(key string, value *string) error {
+	return writePointer(w, key, value, func(v string) string { return v })
+}
+
+// WriteBoolValue writes a Bool value to underlying the byte array.
+func (w *FormSerializationWriter) WriteBoolValue(key string, value *bool) error {
+	return writePointer(w, key, value, strconv.FormatBool)
+}
+
+// WriteInt8Value writes a int8 value to underlying the byte array.
+func (w *FormSerializationWriter) WriteInt8Value(key string, value *int8) error {
+	return writePointer(w, key, value, formatInt[int8])
+}
+
+// WriteByteValue writes a Byte value to underlying the byte array.
+func (w *FormSerializationWriter) WriteByteValue(key string, value *byte) error {
+	return writePointer(w, key, value, formatByte)
+}
+
+// WriteInt32Value writes a Int32 value to underlying the byte array.
+func (w *FormSerializationWriter) WriteInt32Value(key string, value *int32) error {
+	return writePointer(w, key, value, formatInt[int32])
+}
+
+// WriteInt64Value writes a Int64 value to underlying the byte array.
+func (w *FormSerializationWriter) WriteInt64Value(key string, value *int64) error {
+	return writePointer(w, key, value, formatInt[int64])
+}
+
+// WriteFloat32Value writes a Float32 value to underlying the byte array.
+func (w *FormSerializationWriter) WriteFloat32Value(key string, value *float32) error {
+	return writePointer(w, key, value, formatFloat32)
+}
+
+// WriteFloat64Value writes a Float64 value to underlying the byte array.
+func (w *FormSerializationWriter) WriteFloat64Value(key string, value *float64) error {
+	return writePointer(w, key, value, formatFloat64)
+}
+
+// WriteByteArrayValue writes a ByteArray value to underlying the byte array.
+func (w *FormSerializationWriter) WriteByteArrayValue(key string, value []byte) error {
+	if value != nil {
+		w.writeValue(key, base64.StdEncoding.EncodeToString(value))
+	}
+	return nil
+}
+
+// WriteTimeValue writes a Time value to underlying the byte array.
+func (w *FormSerializationWriter) WriteTimeValue(key string, value *time.Time) error {
+	return writePointer(w, key, value, formatTime)
+}
+
+// WriteTimeOnlyValue writes the time part of a Time value to underlying the byte array.
+func (w *FormSerializationWriter) WriteTimeOnlyValue(key string, value *absser.TimeOnly) error {
+	return writePointer(w, key, value, formatStringer[absser.TimeOnly])
+}
+
+// WriteDateOnlyValue writes the date part of a Time value to underlying the byte array.
+func (w *FormSerializationWriter) WriteDateOnlyValue(key string, value *absser.DateOnly) error {
+	return writePointer(w, key, value, formatStringer[absser.DateOnly])
+}
+
+// WriteISODurationValue writes a ISODuration value to underlying the byte array.
+func (w *FormSerializationWriter) WriteISODurationValue(key string, value *absser.ISODuration) error {
+	return writePointer(w, key, value, formatStringer[absser.ISODuration])
+}
+
+// WriteUUIDValue writes a UUID value to underlying the byte array.
+func (w *FormSerializationWriter) WriteUUIDValue(key string, value *uuid.UUID) error {
+	return writePointer(w, key, value, formatStringer[uuid.UUID])
+}
+
+// WriteObjectValue writes a Parsable value to underlying the byte array.
+// Only the root object can be written, nested objects return an error.
+func (w *FormSerializationWriter) WriteObjectValue(key string, item absser.Parsable, additionalValuesToMerge ...absser.Parsable) error {
+	hasValues := !isNil(item)
+	for _, additionalValue := range additionalValuesToMerge {
+		hasValues = hasValues || !isNil(additionalValue)
+	}
+	if !hasValues {
+		return nil
+	}
+	if key != "" || w.depth > 0 {
+		return errNestedObject(key)
+	}
+	w.depth++
+	defer func() { w.depth-- }()
+	if !isNil(item) {
+		if err := w.serializeObject(item); err != nil {
+			return err
+		}
+	}
+	for _, additionalValue := range additionalValuesToMerge {
+		if !isNil(additionalValue) {
+			if err := w.serializeObject(additionalValue); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *FormSerializationWriter) serializeObject(item absser.Parsable) error {
+	if w.onBeforeAssignFieldValues != nil {
+		if err := w.onBeforeAssignFieldValues(item); err != nil {
+			return err
+		}
+	}
+	if w.onStartObjectSerialization != nil {
+		if err := w.onStartObjectSerialization(item, w); err != nil {
+			return err
+		}
+	}
+	if err := item.Serialize(w); err != nil {
+		return err
+	}
+	if w.onAfterAssignFieldValues != nil {
+		return w.onAfterAssignFieldValues(item)
+	}
+	return nil
+}
+
+// WriteCollectionOfObjectValues returns an error as form serialization does not support collections of objects.
+func (w *FormSerializationWriter) WriteCollectionOfObjectValues(key string, collection []absser.Parsable) error {
+	if collection == nil {
+		return nil
+	}
+	return errObjectCollection(key)
+}
+
+// WriteCollectionOfStringValues writes a collection of String values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfStringValues(key string, collection []string) error {
+	return writeCollection(w, key, collection, func(v string) string { return v })
+}
+
+// WriteCollectionOfBoolValues writes a collection of Bool values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfBoolValues(key string, collection []bool) error {
+	return writeCollection(w, key, collection, strconv.FormatBool)
+}
+
+// WriteCollectionOfInt8Values writes a collection of Int8 values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfInt8Values(key string, collection []int8) error {
+	return writeCollection(w, key, collection, formatInt[int8])
+}
+
+// WriteCollectionOfByteValues writes a collection of Byte values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfByteValues(key string, collection []byte) error {
+	return writeCollection(w, key, collection, formatByte)
+}
+
+// WriteCollectionOfInt32Values writes a collection of Int32 values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfInt32Values(key string, collection []int32) error {
+	return writeCollection(w, key, collection, formatInt[int32])
+}
+
+// WriteCollectionOfInt64Values writes a collection of Int64 values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfInt64Values(key string, collection []int64) error {
+	return writeCollection(w, key, collection, formatInt[int64])
+}
+
+// WriteCollectionOfFloat32Values writes a collection of Float32 values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfFloat32Values(key string, collection []float32) error {
+	return writeCollection(w, key, collection, formatFloat32)
+}
+
+// WriteCollectionOfFloat64Values writes a collection of Float64 values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfFloat64Values(key string, collection []float64) error {
+	return writeCollection(w, key, collection, formatFloat64)
+}
+
+// WriteCollectionOfTimeValues writes a collection of Time values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfTimeValues(key string, collection []time.Time) error {
+	return writeCollection(w, key, collection, formatTime)
+}
+
+// WriteCollectionOfISODurationValues writes a collection of ISODuration values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfISODurationValues(key string, collection []absser.ISODuration) error {
+	return writeCollection(w, key, collection, formatStringer[absser.ISODuration])
+}
+
+// WriteCollectionOfDateOnlyValues writes a collection of DateOnly values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfDateOnlyValues(key string, collection []absser.DateOnly) error {
+	return writeCollection(w, key, collection, formatStringer[absser.DateOnly])
+}
+
+// WriteCollectionOfTimeOnlyValues writes a collection of TimeOnly values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfTimeOnlyValues(key string, collection []absser.TimeOnly) error {
+	return writeCollection(w, key, collection, formatStringer[absser.TimeOnly])
+}
+
+// WriteCollectionOfUUIDValues writes a collection of UUID values to underlying the byte array.
+func (w *FormSerializationWriter) WriteCollectionOfUUIDValues(key string, collection []uuid.UUID) error {
+	return writeCollection(w, key, collection, formatStringer[uuid.UUID])
+}
+
+// GetSerializedContent returns the resulting byte array from the serialization writer.
+func (w *FormSerializationWriter) GetSerializedContent() ([]byte, error) {
+	return []byte(w.builder.String()), nil
+}
+
+// WriteNullValue writes a null value for the specified key.
+func (w *FormSerializationWriter) WriteNullValue(key string) error {
+	w.writeValue(key, "null")
+	return nil
+}
+
+// WriteAdditionalData writes additional data to underlying the byte array.
+func (w *FormSerializationWriter) WriteAdditionalData(value map[string]interface{}) error {
+	for key, input := range value {
+		if err := w.WriteAnyValue(key, input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteAnyValue writes a primitive value or a collection of primitive values of unknown type.
+// Objects and collections of objects return an error.
+func (w *FormSerializationWriter) WriteAnyValue(key string, value interface{}) error {
+	if isNil(value) {
+		return w.WriteNullValue(key)
+	}
+	switch v := value.(type) {
+	case absser.Parsable:
+		return errNestedObject(key)
+	case []absser.Parsable:
+		return errObjectCollection(key)
+	case []string:
+		return w.WriteCollectionOfStringValues(key, v)
+	case []bool:
+		return w.WriteCollectionOfBoolValues(key, v)
+	case []byte:
+		return w.WriteByteArrayValue(key, v)
+	case []int8:
+		return w.WriteCollectionOfInt8Values(key, v)
+	case []int32:
+		return w.WriteCollectionOfInt32Values(key, v)
+	case []int64:
+		return w.WriteCollectionOfInt64Values(key, v)
+	case []float32:
+		return w.WriteCollectionOfFloat32Values(key, v)
+	case []float64:
+		return w.WriteCollectionOfFloat64Values(key, v)
+	case []uuid.UUID:
+		return w.WriteCollectionOfUUIDValues(key, v)
+	case []time.Time:
+		return w.WriteCollectionOfTimeValues(key, v)
+	case []absser.ISODuration:
+		return w.WriteCollectionOfISODurationValues(key, v)
+	case []absser.DateOnly:
+		return w.WriteCollectionOfDateOnlyValues(key, v)
+	case []absser.TimeOnly:
+		return w.WriteCollectionOfTimeOnlyValues(key, v)
+	case []interface{}:
+		for _, item := range v {
+			if isNil(item) {
+				continue
+			}
+			formatted, ok := formatPrimitive(item)
+			if !ok {
+				if _, isParsable := item.(absser.Parsable); isParsable {
+					return errObjectCollection(key)
+				}
+				return fmt.Errorf("unsupported AdditionalData type %T for %q", item, key)
+			}
+			w.writeValue(key, formatted)
+		}
+		return nil
+	}
+	if formatted, ok := formatPrimitive(value); ok {
+		w.writeValue(key, formatted)
+		return nil
+	}
+	if kind := reflect.ValueOf(value).Kind(); kind == reflect.Map || kind == reflect.Struct {
+		return errNestedObject(key)
+	}
+	return fmt.Errorf("unsupported AdditionalData type %T for %q", value, key)
+}
+
+// formatPrimitive formats a primitive value or a pointer to a primitive value.
+func formatPrimitive(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case *string:
+		return *v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case *bool:
+		return strconv.FormatBool(*v), true
+	case byte:
+		return formatByte(v), true
+	case *byte:
+		return formatByte(*v), true
+	case int8:
+		return formatInt(v), true
+	case *int8:
+		return formatInt(*v), true
+	case int32:
+		return formatInt(v), true
+	case *int32:
+		return formatInt(*v), true
+	case int64:
+		return formatInt(v), true
+	case *int64:
+		return formatInt(*v), true
+	case int:
+		return formatInt(v), true
+	case *int:
+		return formatInt(*v), true
+	case float32:
+		return formatFloat32(v), true
+	case *float32:
+		return formatFloat32(*v), true
+	case float64:
+		return formatFloat64(v), true
+	case *float64:
+		return formatFloat64(*v), true
+	case time.Time:
+		return formatTime(v), true
+	case *time.Time:
+		return formatTime(*v), true
+	case fmt.Stringer:
+		// uuid.UUID, DateOnly, TimeOnly, ISODuration and enums
+		if _, isParsable := v.(absser.Parsable); isParsable {
+			return "", false
+		}
+		return v.String(), true
+	}
+	return "", false
+}
+
+// GetOnBeforeSerialization returns a callback invoked before the serialization process starts.
+func (w *FormSerializationWriter) GetOnBeforeSerialization() absser.ParsableAction {
+	return w.onBeforeAssignFieldValues
+}
+
+// SetOnBeforeSerialization sets a callback invoked before the serialization process starts.
+func (w *FormSerializationWriter) SetOnBeforeSerialization(action absser.ParsableAction) error {
+	w.onBeforeAssignFieldValues = action
+	return nil
+}
+
+// GetOnAfterObjectSerialization returns a callback invoked after the serialization process completes.
+func (w *FormSerializationWriter) GetOnAfterObjectSerialization() absser.ParsableAction {
+	return w.onAfterAssignFieldValues
+}
+
+// SetOnAfterObjectSerialization sets a callback invoked after the serialization process completes.
+func (w *FormSerializationWriter) SetOnAfterObjectSerialization(action absser.ParsableAction) error {
+	w.onAfterAssignFieldValues = action
+	return nil
+}
+
+// GetOnStartObjectSerialization returns a callback invoked right after the serialization process starts.
+func (w *FormSerializationWriter) GetOnStartObjectSerialization() absser.ParsableWriter {
+	return w.onStartObjectSerialization
+}
+
+// SetOnStartObjectSerialization sets a callback invoked right after the serialization process starts.
+func (w *FormSerializationWriter) SetOnStartObjectSerialization(writer absser.ParsableWriter) error {
+	w.onStartObjectSerialization = writer
+	return nil
+}
+
+// Close clears the internal buffer.
+func (w *FormSerializationWriter) Close() error {
+	w.builder.Reset()
+	return nil
+}
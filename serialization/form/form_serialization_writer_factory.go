@@ -0,0 +1,40 @@
+package form
+
+import (
+	"errors"
+	"strings"
+
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+)
+
+// FormContentType is the content type handled by the form serialization writer and parse node factories.
+const FormContentType = "application/x-www-form-urlencoded"
+
+// FormSerializationWriterFactory implements SerializationWriterFactory for URL form encoded content.
+type FormSerializationWriterFactory struct {
+}
+
+// NewFormSerializationWriterFactory creates a new instance of the FormSerializationWriterFactory.
+func NewFormSerializationWriterFactory() *FormSerializationWriterFactory {
+	return &FormSerializationWriterFactory{}
+}
+
+// GetValidContentType returns the valid content type for the SerializationWriterFactoryRegistry
+func (f *FormSerializationWriterFactory) GetValidContentType() (string, error) {
+	return FormContentType, nil
+}
+
+// GetSerializationWriter returns the relevant SerializationWriter instance for the given content type
+func (f *FormSerializationWriterFactory) GetSerializationWriter(contentType string) (absser.SerializationWriter, error) {
+	if contentType == "" {
+		return nil, errors.New("the content type is empty")
+	} else if mediaType(contentType) != FormContentType {
+		return nil, errors.New("the provided content type is not supported")
+	}
+	return NewFormSerializationWriter(), nil
+}
+
+// mediaType returns the content type without its parameters.
+func mediaType(contentType string) string {
+	return strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+}
@@ -0,0 +1,109 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/microsoft/kiota-abstractions-go/internal"
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormSerializationWriterHonoursInterface(t *testing.T) {
+	assert.Implements(t, (*absser.SerializationWriter)(nil), NewFormSerializationWriter())
+}
+
+func TestFormSerializationWriterWritesObject(t *testing.T) {
+	name := "John Doe & co"
+	count := int32(3)
+	status := internal.SUSPENDED
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	entity := &testEntity{
+		name:           &name,
+		count:          &count,
+		tags:           []string{"a", "b c"},
+		status:         &status,
+		previousStatus: []internal.PersonStatus{internal.ACTIVE, internal.SUSPENDED},
+		birthday:       absser.NewDateOnly(time.Date(2000, 5, 6, 0, 0, 0, 0, time.UTC)),
+		startTime:      absser.NewTimeOnly(time.Date(0, 1, 1, 8, 30, 0, 0, time.UTC)),
+		duration:       absser.NewDuration(0, 0, 1, 2, 0, 0, 0),
+		createdAt:      &createdAt,
+	}
+	writer := NewFormSerializationWriter()
+	err := writer.WriteObjectValue("", entity)
+	assert.Nil(t, err)
+	content, err := writer.GetSerializedContent()
+	assert.Nil(t, err)
+	assert.Equal(t, "name=John+Doe+%26+co&count=3&tags=a&tags=b+c&status=suspended&previousStatus=active&previousStatus=suspended&birthday=2000-05-06&startTime=08%3A30%3A00&duration=P1DT2H&createdAt=2024-01-02T03%3A04%3A05Z", string(content))
+}
+
+func TestFormSerializationWriterWritesAdditionalData(t *testing.T) {
+	entity := &testEntity{
+		additionalData: map[string]interface{}{
+			"values": []interface{}{"x", 2},
+		},
+	}
+	writer := NewFormSerializationWriter()
+	err := writer.WriteObjectValue("", entity)
+	assert.Nil(t, err)
+	content, err := writer.GetSerializedContent()
+	assert.Nil(t, err)
+	assert.Equal(t, "values=x&values=2", string(content))
+}
+
+func TestFormSerializationWriterWritesNullValue(t *testing.T) {
+	writer := NewFormSerializationWriter()
+	err := writer.WriteNullValue("key")
+	assert.Nil(t, err)
+	content, _ := writer.GetSerializedContent()
+	assert.Equal(t, "key=null", string(content))
+}
+
+func TestFormSerializationWriterRejectsNestedObjects(t *testing.T) {
+	entity := &testEntity{nested: &testEntity{}}
+	writer := NewFormSerializationWriter()
+	err := writer.WriteObjectValue("", entity)
+	assert.EqualError(t, err, `form serialization does not support nested objects, the value of "nested" is an object`)
+
+	err = writer.WriteCollectionOfObjectValues("items", []absser.Parsable{&testEntity{}})
+	assert.EqualError(t, err, `form serialization does not support collections of objects, the value of "items" is a collection of objects`)
+
+	err = writer.WriteAdditionalData(map[string]interface{}{"map": map[string]interface{}{"a": "b"}})
+	assert.EqualError(t, err, `form serialization does not support nested objects, the value of "map" is an object`)
+}
+
+func TestFormSerializationWriterCallsHooks(t *testing.T) {
+	writer := NewFormSerializationWriter()
+	calls := make([]string, 0)
+	_ = writer.SetOnBeforeSerialization(func(absser.Parsable) error {
+		calls = append(calls, "before")
+		return nil
+	})
+	_ = writer.SetOnStartObjectSerialization(func(absser.Parsable, absser.SerializationWriter) error {
+		calls = append(calls, "start")
+		return nil
+	})
+	_ = writer.SetOnAfterObjectSerialization(func(absser.Parsable) error {
+		calls = append(calls, "after")
+		return nil
+	})
+	err := writer.WriteObjectValue("", &testEntity{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"before", "start", "after"}, calls)
+}
+
+func TestFormSerializationWriterFactory(t *testing.T) {
+	factory := NewFormSerializationWriterFactory()
+	contentType, err := factory.GetValidContentType()
+	assert.Nil(t, err)
+	assert.Equal(t, "application/x-www-form-urlencoded", contentType)
+
+	writer, err := factory.GetSerializationWriter(contentType)
+	assert.Nil(t, err)
+	assert.NotNil(t, writer)
+
+	_, err = factory.GetSerializationWriter("application/json")
+	assert.NotNil(t, err)
+	_, err = factory.GetSerializationWriter("")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,150 @@
+package form
+
+import (
+	"errors"
+	"time"
+
+	"github.com/microsoft/kiota-abstractions-go/internal"
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+)
+
+type testEntity struct {
+	name           *string
+	count          *int32
+	tags           []string
+	status         *internal.PersonStatus
+	previousStatus []internal.PersonStatus
+	birthday       *absser.DateOnly
+	startTime      *absser.TimeOnly
+	duration       *absser.ISODuration
+	createdAt      *time.Time
+	nested         *testEntity
+	additionalData map[string]interface{}
+}
+
+func createTestEntityFromDiscriminatorValue(parseNode absser.ParseNode) (absser.Parsable, error) {
+	return &testEntity{}, nil
+}
+
+func (e *testEntity) GetAdditionalData() map[string]interface{} {
+	return e.additionalData
+}
+
+func (e *testEntity) SetAdditionalData(value map[string]interface{}) {
+	e.additionalData = value
+}
+
+func (e *testEntity) Serialize(writer absser.SerializationWriter) error {
+	if err := writer.WriteStringValue("name", e.name); err != nil {
+		return err
+	}
+	if err := writer.WriteInt32Value("count", e.count); err != nil {
+		return err
+	}
+	if err := writer.WriteCollectionOfStringValues("tags", e.tags); err != nil {
+		return err
+	}
+	if e.status != nil {
+		status := e.status.String()
+		if err := writer.WriteStringValue("status", &status); err != nil {
+			return err
+		}
+	}
+	if e.previousStatus != nil {
+		if err := writer.WriteCollectionOfStringValues("previousStatus", internal.SerializePersonStatus(e.previousStatus)); err != nil {
+			return err
+		}
+	}
+	if err := writer.WriteDateOnlyValue("birthday", e.birthday); err != nil {
+		return err
+	}
+	if err := writer.WriteTimeOnlyValue("startTime", e.startTime); err != nil {
+		return err
+	}
+	if err := writer.WriteISODurationValue("duration", e.duration); err != nil {
+		return err
+	}
+	if err := writer.WriteTimeValue("createdAt", e.createdAt); err != nil {
+		return err
+	}
+	if e.nested != nil {
+		if err := writer.WriteObjectValue("nested", e.nested); err != nil {
+			return err
+		}
+	}
+	return writer.WriteAdditionalData(e.additionalData)
+}
+
+func (e *testEntity) GetFieldDeserializers() map[string]func(absser.ParseNode) error {
+	return map[string]func(absser.ParseNode) error{
+		"name": func(n absser.ParseNode) error {
+			val, err := n.GetStringValue()
+			e.name = val
+			return err
+		},
+		"count": func(n absser.ParseNode) error {
+			val, err := n.GetInt32Value()
+			e.count = val
+			return err
+		},
+		"tags": func(n absser.ParseNode) error {
+			val, err := n.GetCollectionOfPrimitiveValues("string")
+			if err != nil {
+				return err
+			}
+			e.tags = nil
+			for _, v := range val {
+				e.tags = append(e.tags, *(v.(*string)))
+			}
+			return nil
+		},
+		"status": func(n absser.ParseNode) error {
+			val, err := n.GetEnumValue(internal.ParsePersonStatus)
+			if val != nil {
+				e.status = val.(*internal.PersonStatus)
+			}
+			return err
+		},
+		"previousStatus": func(n absser.ParseNode) error {
+			val, err := n.GetCollectionOfEnumValues(internal.ParsePersonStatus)
+			if err != nil {
+				return err
+			}
+			for _, v := range val {
+				e.previousStatus = append(e.previousStatus, *(v.(*internal.PersonStatus)))
+			}
+			return nil
+		},
+		"birthday": func(n absser.ParseNode) error {
+			val, err := n.GetDateOnlyValue()
+			e.birthday = val
+			return err
+		},
+		"startTime": func(n absser.ParseNode) error {
+			val, err := n.GetTimeOnlyValue()
+			e.startTime = val
+			return err
+		},
+		"duration": func(n absser.ParseNode) error {
+			val, err := n.GetISODurationValue()
+			e.duration = val
+			return err
+		},
+		"createdAt": func(n absser.ParseNode) error {
+			val, err := n.GetTimeValue()
+			e.createdAt = val
+			return err
+		},
+		"nested": func(n absser.ParseNode) error {
+			val, err := n.GetObjectValue(createTestEntityFromDiscriminatorValue)
+			if err != nil {
+				return err
+			}
+			if val == nil {
+				return errors.New("nested value is nil")
+			}
+			e.nested = val.(*testEntity)
+			return nil
+		},
+	}
+}
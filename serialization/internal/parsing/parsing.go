@@ -0,0 +1,50 @@
+// Package parsing holds the parsers of primitive values shared by the parse nodes reading text representations.
+package parsing
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Int returns a parser of base 10 integers fitting in the bit size.
+func Int[T int8 | int32 | int64](bitSize int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		v, err := strconv.ParseInt(s, 10, bitSize)
+		return T(v), err
+	}
+}
+
+// Float returns a parser of floating point numbers fitting in the bit size.
+func Float[T float32 | float64](bitSize int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		v, err := strconv.ParseFloat(s, bitSize)
+		return T(v), err
+	}
+}
+
+// Byte parses an unsigned base 10 integer fitting in a byte.
+func Byte(s string) (byte, error) {
+	v, err := strconv.ParseUint(s, 10, 8)
+	return byte(v), err
+}
+
+// Time parses an RFC 3339 date and time.
+func Time(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339, s)
+}
+
+// Dereference adapts a parser returning a pointer, a nil result is an error.
+func Dereference[T any](parse func(string) (*T, error)) func(string) (T, error) {
+	return func(s string) (T, error) {
+		var zero T
+		v, err := parse(s)
+		if err != nil {
+			return zero, err
+		}
+		if v == nil {
+			return zero, errors.New("value is empty")
+		}
+		return *v, nil
+	}
+}
@@ -1,9 +1,24 @@
 package serialization
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
 	"time"
 )
 
+// ErrIndeterminateDurationComparison is returned when the order of two durations depends on the date they are applied to, e.g. P1M and P30D.
+var ErrIndeterminateDurationComparison = errors.New("the order of the durations depends on the date they are applied to")
+
+// durationComparisonReferences are the dates the XML Schema specification uses to order durations.
+var durationComparisonReferences = []time.Time{
+	time.Date(1696, time.September, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1697, time.February, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1903, time.March, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1903, time.July, 1, 0, 0, 0, 0, time.UTC),
+}
+
 // ISODuration represents an ISO 8601 duration
 type ISODuration struct {
 	duration duration
@@ -14,6 +29,11 @@ func (i ISODuration) GetYears() int {
 	return i.duration.Years
 }
 
+// GetMonths returns the number of months.
+func (i ISODuration) GetMonths() int {
+	return i.duration.Months
+}
+
 // GetWeeks returns the number of weeks.
 func (i ISODuration) GetWeeks() int {
 	return i.duration.Weeks
@@ -45,40 +65,55 @@ func (i ISODuration) GetMilliSeconds() int {
 }
 
 // SetYears sets the number of years.
-func (i ISODuration) SetYears(years int) {
+func (i *ISODuration) SetYears(years int) {
 	i.duration.Years = years
 }
 
+// SetMonths sets the number of months.
+func (i *ISODuration) SetMonths(months int) {
+	i.duration.Months = months
+}
+
 // SetWeeks sets the number of weeks.
-func (i ISODuration) SetWeeks(weeks int) {
+func (i *ISODuration) SetWeeks(weeks int) {
 	i.duration.Weeks = weeks
 }
 
 // SetDays sets the number of days.
-func (i ISODuration) SetDays(days int) {
+func (i *ISODuration) SetDays(days int) {
 	i.duration.Days = days
 }
 
 // SetHours sets the number of hours.
-func (i ISODuration) SetHours(hours int) {
+func (i *ISODuration) SetHours(hours int) {
 	i.duration.Hours = hours
 }
 
 // SetMinutes sets the number of minutes.
-func (i ISODuration) SetMinutes(minutes int) {
+func (i *ISODuration) SetMinutes(minutes int) {
 	i.duration.Minutes = minutes
 }
 
 // SetSeconds sets the number of seconds.
-func (i ISODuration) SetSeconds(seconds int) {
+func (i *ISODuration) SetSeconds(seconds int) {
 	i.duration.Seconds = seconds
 }
 
 // SetMilliSeconds sets the number of milliseconds.
-func (i ISODuration) SetMilliSeconds(milliSeconds int) {
+func (i *ISODuration) SetMilliSeconds(milliSeconds int) {
 	i.duration.MilliSeconds = milliSeconds
 }
 
+// IsNegative returns whether the duration is negative.
+func (i ISODuration) IsNegative() bool {
+	return i.duration.Negative
+}
+
+// SetNegative sets whether the duration is negative.
+func (i *ISODuration) SetNegative(negative bool) {
+	i.duration.Negative = negative
+}
+
 // ParseISODuration parses a string into an ISODuration following the ISO 8601 standard.
 func ParseISODuration(s string) (*ISODuration, error) {
 	d, err := durationFromString(s)
@@ -105,17 +140,217 @@ func NewDuration(years int, weeks int, days int, hours int, minutes int, seconds
 	}
 }
 
+// NewISODuration creates a new ISODuration from primitive values including months.
+func NewISODuration(years int, months int, weeks int, days int, hours int, minutes int, seconds int, milliSeconds int) *ISODuration {
+	result := NewDuration(years, weeks, days, hours, minutes, seconds, milliSeconds)
+	result.duration.Months = months
+	return result
+}
+
 // String returns the ISO 8601 representation of the duration.
+// Durations whose calendar and time components have different signs are written component by component and cannot be parsed back.
 func (i ISODuration) String() string {
 	return i.duration.string()
 }
 
 // FromDuration returns an ISODuration from a time.Duration.
 func FromDuration(d time.Duration) *ISODuration {
-	return NewDuration(0, 0, 0, 0, 0, 0, int(d.Truncate(time.Millisecond).Milliseconds()))
+	result := NewDuration(0, 0, 0, 0, 0, 0, int(d.Truncate(time.Millisecond).Milliseconds()))
+	_ = result.duration.normalize()
+	return result
 }
 
-// ToDuration returns the time.Duration representation of the ISODuration.
+// ToDuration returns the time.Duration representation of the ISODuration, counting a year as 365 days.
+// Durations with months are rejected as their length depends on the start, use ToDurationFrom instead.
 func (d ISODuration) ToDuration() (time.Duration, error) {
 	return d.duration.toDuration()
 }
+
+// ToDurationFrom returns the time.Duration the ISODuration represents when applied to the start time.
+func (i ISODuration) ToDurationFrom(start time.Time) time.Duration {
+	return i.AddTo(start).Sub(start)
+}
+
+// Normalize returns a copy of the duration where every component is carried over to the largest unit with a fixed length.
+func (i ISODuration) Normalize() (*ISODuration, error) {
+	result := &ISODuration{duration: i.duration}
+	if err := result.duration.normalize(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Negate returns a copy of the duration with the opposite sign.
+func (i ISODuration) Negate() *ISODuration {
+	result := &ISODuration{duration: i.duration}
+	result.duration.Negative = !result.duration.Negative
+	return result
+}
+
+// scale returns the duration with every component multiplied by the factor, without normalizing it.
+func (i ISODuration) scale(factor int) ISODuration {
+	d := i.duration
+	if factor < 0 {
+		d.Negative = !d.Negative
+		factor = -factor
+	}
+	d.Years *= factor
+	d.Months *= factor
+	d.Weeks *= factor
+	d.Days *= factor
+	d.Hours *= factor
+	d.Minutes *= factor
+	d.Seconds *= factor
+	d.MilliSeconds *= factor
+	return ISODuration{duration: d}
+}
+
+// AddTo adds the duration to the time, years and months are added first and the day is clamped to the end of the month, e.g. P1M added to January 31st results in the end of February.
+func (i ISODuration) AddTo(t time.Time) time.Time {
+	d := i.duration
+	sign := 1
+	if d.Negative {
+		sign = -1
+	}
+	t = addMonths(t, sign*(d.Years*moToY+d.Months))
+	t = t.AddDate(0, 0, sign*(d.Weeks*dToW+d.Days))
+	return t.Add(time.Duration(sign) * (time.Duration(d.Hours)*time.Hour +
+		time.Duration(d.Minutes)*time.Minute +
+		time.Duration(d.Seconds)*time.Second +
+		time.Duration(d.MilliSeconds)*time.Millisecond))
+}
+
+// Between returns the calendar duration between start and end, which is negative when end is before start.
+// The result holds whole months, then whole days and the remaining time truncated to the millisecond.
+func Between(start time.Time, end time.Time) *ISODuration {
+	if end.Before(start) {
+		result := Between(end, start)
+		result.duration.Negative = !result.IsZero()
+		return result
+	}
+	end = end.In(start.Location())
+
+	months := (end.Year()-start.Year())*moToY + int(end.Month()-start.Month())
+	for months > 0 && addMonths(start, months).After(end) {
+		months--
+	}
+	cursor := addMonths(start, months)
+
+	days := int(end.Sub(cursor) / (hToD * time.Hour))
+	for days > 0 && cursor.AddDate(0, 0, days).After(end) {
+		days--
+	}
+	for !cursor.AddDate(0, 0, days+1).After(end) {
+		days++
+	}
+	cursor = cursor.AddDate(0, 0, days)
+
+	result := &ISODuration{
+		duration: duration{
+			Months:       months,
+			Days:         days,
+			MilliSeconds: int(end.Sub(cursor).Milliseconds()),
+		},
+	}
+	_ = result.duration.normalize()
+	return result
+}
+
+// IsZero returns whether the duration has no length.
+func (i ISODuration) IsZero() bool {
+	return i.duration.calendarMonths() == 0 && i.duration.fixedMilliSeconds() == 0
+}
+
+// Compare returns -1, 0 or 1 when the duration is respectively shorter, equal or longer than the other duration.
+// Durations are ordered by applying them to reference dates, it returns ErrIndeterminateDurationComparison when the order depends on the date, e.g. P1M and P30D.
+func (i ISODuration) Compare(other ISODuration) (int, error) {
+	result := 0
+	for index, reference := range durationComparisonReferences {
+		comparison := i.AddTo(reference).Compare(other.AddTo(reference))
+		if index == 0 {
+			result = comparison
+		} else if comparison != result {
+			return 0, ErrIndeterminateDurationComparison
+		}
+	}
+	return result, nil
+}
+
+// Equal returns whether both durations have the same length regardless of the date they are applied to, e.g. PT60M and PT1H are equal.
+func (i ISODuration) Equal(other ISODuration) bool {
+	result, err := i.Compare(other)
+	return err == nil && result == 0
+}
+
+// addMonths adds the number of months to the time and clamps the day to the last day of the resulting month.
+func addMonths(t time.Time, months int) time.Time {
+	if months == 0 {
+		return t
+	}
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// MarshalText implements encoding.TextMarshaler, durations mixing signs cannot be represented and return an error.
+func (i ISODuration) MarshalText() ([]byte, error) {
+	text, err := i.duration.text()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(text), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, an empty text results in the zero value.
+func (i *ISODuration) UnmarshalText(text []byte) error {
+	if len(strings.TrimSpace(string(text))) == 0 {
+		*i = ISODuration{}
+		return nil
+	}
+	value, err := ParseISODuration(string(text))
+	if err != nil {
+		return err
+	}
+	*i = *value
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, durations mixing signs cannot be represented and return an error.
+func (i ISODuration) MarshalJSON() ([]byte, error) {
+	text, err := i.duration.text()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(text)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, null leaves the value unchanged.
+func (i *ISODuration) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, i)
+}
+
+// Value implements driver.Valuer, the duration is stored as its ISO 8601 representation and durations mixing signs return an error.
+func (i ISODuration) Value() (driver.Value, error) {
+	text, err := i.duration.text()
+	if err != nil {
+		return nil, err
+	}
+	return text, nil
+}
+
+// Scan implements sql.Scanner, it accepts ISO 8601 text values, null results in the zero value.
+func (i *ISODuration) Scan(src any) error {
+	if src == nil {
+		*i = ISODuration{}
+		return nil
+	}
+	return scanText(src, "ISODuration", i)
+}
+
+// Set implements flag.Value.
+func (i *ISODuration) Set(value string) error {
+	return i.UnmarshalText([]byte(value))
+}
@@ -1,9 +1,25 @@
 package serialization
 
 import (
-	assert "github.com/stretchr/testify/assert"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"flag"
 	"testing"
 	"time"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+var (
+	_ encoding.TextMarshaler   = ISODuration{}
+	_ encoding.TextUnmarshaler = &ISODuration{}
+	_ json.Marshaler           = ISODuration{}
+	_ json.Unmarshaler         = &ISODuration{}
+	_ driver.Valuer            = ISODuration{}
+	_ sql.Scanner              = &ISODuration{}
+	_ flag.Value               = &ISODuration{}
 )
 
 func TestItParsesADuration(t *testing.T) {
@@ -43,3 +59,208 @@ func TestItMakesAnNewISODurationFor1yAnd1dAnd1h(t *testing.T) {
 	isoDuration := NewDuration(1, 0, 1, 1, 0, 0, 0)
 	assert.Equal(t, "P1Y1DT1H", isoDuration.String())
 }
+
+func TestSettersUpdateTheDuration(t *testing.T) {
+	isoDuration := NewDuration(0, 0, 0, 0, 0, 0, 0)
+	isoDuration.SetYears(1)
+	isoDuration.SetMonths(2)
+	isoDuration.SetDays(3)
+	isoDuration.SetHours(4)
+	isoDuration.SetMinutes(5)
+	isoDuration.SetSeconds(6)
+	isoDuration.SetMilliSeconds(7)
+	assert.Equal(t, 2, isoDuration.GetMonths())
+	assert.Equal(t, "P1Y2M3DT4H5M6.007S", isoDuration.String())
+
+	isoDuration.SetNegative(true)
+	assert.True(t, isoDuration.IsNegative())
+	assert.Equal(t, "-P1Y2M3DT4H5M6.007S", isoDuration.String())
+}
+
+func TestItParsesFullDurations(t *testing.T) {
+	cases := map[string]string{
+		"P1Y2M3DT4H5M6S": "P1Y2M3DT4H5M6S",
+		"-P1M":           "-P1M",
+		"+P2W":           "P2W",
+		"PT0.5S":         "PT0.5S",
+		"PT1,5M":         "PT1M30S",
+		"P1W1D":          "P8D",
+		"PT36H":          "P1DT12H",
+	}
+	for input, expected := range cases {
+		isoDuration, err := ParseISODuration(input)
+		assert.Nil(t, err, input)
+		assert.Equal(t, expected, isoDuration.String(), input)
+	}
+}
+
+func TestItMakesAnISODurationFromANegativeTimeDuration(t *testing.T) {
+	isoDuration := FromDuration(-90 * time.Minute)
+	assert.True(t, isoDuration.IsNegative())
+	assert.Equal(t, 1, isoDuration.GetHours())
+	assert.Equal(t, 30, isoDuration.GetMinutes())
+	assert.Equal(t, "-PT1H30M", isoDuration.String())
+
+	result, err := isoDuration.ToDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, -90*time.Minute, result)
+}
+
+func TestNormalizeReturnsACopy(t *testing.T) {
+	isoDuration := NewDuration(0, 0, 0, 0, 90, 0, 0)
+	normalized, err := isoDuration.Normalize()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, normalized.GetHours())
+	assert.Equal(t, 30, normalized.GetMinutes())
+	assert.Equal(t, 90, isoDuration.GetMinutes())
+
+	_, err = NewISODuration(0, 1, 0, -1, 0, 0, 0, 0).Normalize()
+	assert.NotNil(t, err)
+}
+
+func TestAddToClampsToTheEndOfTheMonth(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2024, time.February, 29, 10, 0, 0, 0, time.UTC), NewISODuration(0, 1, 0, 0, 0, 0, 0, 0).AddTo(start))
+	assert.Equal(t, time.Date(2025, time.March, 1, 12, 30, 0, 0, time.UTC), NewISODuration(1, 1, 0, 1, 2, 30, 0, 0).AddTo(start))
+	assert.Equal(t, time.Date(2023, time.December, 31, 10, 0, 0, 0, time.UTC), NewISODuration(0, 1, 0, 0, 0, 0, 0, 0).Negate().AddTo(start))
+	assert.Equal(t, time.Date(2023, time.November, 30, 10, 0, 0, 0, time.UTC), NewISODuration(0, 2, 0, 0, 0, 0, 0, 0).Negate().AddTo(start))
+}
+
+func TestAddToKeepsTheWallClockAcrossDaylightSavingTime(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+	start := time.Date(2024, time.March, 30, 12, 0, 0, 0, location)
+
+	assert.Equal(t, time.Date(2024, time.March, 31, 12, 0, 0, 0, location), NewDuration(0, 0, 1, 0, 0, 0, 0).AddTo(start))
+	assert.Equal(t, time.Date(2024, time.March, 31, 13, 0, 0, 0, location), NewDuration(0, 0, 0, 24, 0, 0, 0).AddTo(start))
+	assert.Equal(t, 23*time.Hour, NewDuration(0, 0, 1, 0, 0, 0, 0).ToDurationFrom(start))
+}
+
+func TestToDurationFromSupportsMonths(t *testing.T) {
+	isoDuration, err := ParseISODuration("P1M")
+	assert.Nil(t, err)
+	assert.Equal(t, 29*24*time.Hour, isoDuration.ToDurationFrom(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 31*24*time.Hour, isoDuration.ToDurationFrom(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestBetweenReturnsTheCalendarDuration(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.March, 2, 12, 30, 15, 250000000, time.UTC)
+
+	result := Between(start, end)
+	assert.Equal(t, "P1Y1M2DT2H30M15.25S", result.String())
+	assert.Equal(t, end, result.AddTo(start))
+
+	reversed := Between(end, start)
+	assert.True(t, reversed.IsNegative())
+	assert.Equal(t, "-P1Y1M2DT2H30M15.25S", reversed.String())
+
+	assert.Equal(t, "PT0S", Between(start, start).String())
+	assert.Equal(t, "P1M", Between(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, time.March, 29, 0, 0, 0, 0, time.UTC)).String())
+	assert.Equal(t, "P2W", Between(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.February, 15, 0, 0, 0, 0, time.UTC)).String())
+}
+
+func TestCompareDurations(t *testing.T) {
+	oneHour, _ := ParseISODuration("PT1H")
+	sixtyMinutes, _ := ParseISODuration("PT60M")
+	oneDay, _ := ParseISODuration("P1D")
+	oneMonth, _ := ParseISODuration("P1M")
+	thirtyDays, _ := ParseISODuration("P30D")
+	thirtyTwoDays, _ := ParseISODuration("P32D")
+
+	result, err := oneHour.Compare(*oneDay)
+	assert.Nil(t, err)
+	assert.Equal(t, -1, result)
+
+	result, err = oneMonth.Compare(*thirtyTwoDays)
+	assert.Nil(t, err)
+	assert.Equal(t, -1, result)
+
+	result, err = oneDay.Negate().Compare(*oneHour.Negate())
+	assert.Nil(t, err)
+	assert.Equal(t, -1, result)
+
+	_, err = oneMonth.Compare(*thirtyDays)
+	assert.Equal(t, ErrIndeterminateDurationComparison, err)
+
+	assert.True(t, oneHour.Equal(*sixtyMinutes))
+	assert.False(t, oneMonth.Equal(*thirtyDays))
+}
+
+func TestISODurationJson(t *testing.T) {
+	type model struct {
+		Duration ISODuration  `json:"duration"`
+		Optional *ISODuration `json:"optional"`
+	}
+
+	content, err := json.Marshal(model{Duration: *NewDuration(0, 0, 1, 2, 0, 0, 0)})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"duration":"P1DT2H","optional":null}`, string(content))
+
+	var result model
+	assert.Nil(t, json.Unmarshal([]byte(`{"duration":"-P1M","optional":"PT30M"}`), &result))
+	assert.Equal(t, "-P1M", result.Duration.String())
+	assert.Equal(t, "PT30M", result.Optional.String())
+	assert.NotNil(t, json.Unmarshal([]byte(`{"duration":"1 hour"}`), &result))
+}
+
+func TestISODurationSql(t *testing.T) {
+	value, err := NewDuration(0, 0, 0, 1, 30, 0, 0).Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "PT1H30M", value)
+
+	var result ISODuration
+	assert.Nil(t, result.Scan("P2W"))
+	assert.Equal(t, 2, result.GetWeeks())
+	assert.Nil(t, result.Scan([]byte("PT5S")))
+	assert.Equal(t, 5, result.GetSeconds())
+	assert.Nil(t, result.Scan(nil))
+	assert.True(t, result.IsZero())
+	assert.EqualError(t, result.Scan(time.Now()), "cannot scan a value of type time.Time into a ISODuration")
+}
+
+func TestISODurationMixingSignsIsNotMarshaled(t *testing.T) {
+	mixed := NewISODuration(0, 1, 0, -1, 0, 0, 0, 0)
+	assert.Equal(t, "P0Y1M0W-1DT0H0M0S", mixed.String())
+	_, err := mixed.MarshalText()
+	assert.Equal(t, errMixedSigns, err)
+	_, err = json.Marshal(mixed)
+	assert.ErrorIs(t, err, errMixedSigns)
+	_, err = mixed.Value()
+	assert.Equal(t, errMixedSigns, err)
+
+	withMilliSeconds := NewISODuration(0, 1, 0, 0, 0, 0, -1, -500)
+	assert.Equal(t, "P0Y1M0W0DT0H0M-1.5S", withMilliSeconds.String())
+	_, err = withMilliSeconds.MarshalText()
+	assert.Equal(t, errMixedSigns, err)
+}
+
+func TestISODurationWithNegativeMilliSecondsRoundTrips(t *testing.T) {
+	for _, isoDuration := range []*ISODuration{
+		NewDuration(0, 0, 0, 0, 0, -1, -500),
+		NewDuration(0, 0, 0, 0, 0, 0, -250),
+		NewDuration(0, 0, 0, 0, 0, 2, -500),
+		FromDuration(-1500 * time.Millisecond),
+	} {
+		text, err := isoDuration.MarshalText()
+		assert.Nil(t, err)
+		var result ISODuration
+		assert.Nil(t, result.UnmarshalText(text), string(text))
+		assert.True(t, isoDuration.Equal(result), string(text))
+	}
+	text, _ := NewDuration(0, 0, 0, 0, 0, -1, -500).MarshalText()
+	assert.Equal(t, "-PT1.5S", string(text))
+}
+
+func TestISODurationFlag(t *testing.T) {
+	var isoDuration ISODuration
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Var(&isoDuration, "timeout", "a timeout")
+
+	assert.Nil(t, flags.Parse([]string{"-timeout", "PT1M30S"}))
+	assert.Equal(t, "PT1M30S", isoDuration.String())
+	assert.NotNil(t, isoDuration.Set("90s"))
+}
@@ -0,0 +1,280 @@
+package serialization
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnboundedRecurrence is returned when expanding a recurrence without an end and without a limit.
+	ErrUnboundedRecurrence = errors.New("the recurrence has no end, a limit is required")
+	// ErrRecurrenceTooLong is returned when expanding a recurrence with more than MaxExpandedOccurrences repetitions without a limit.
+	ErrRecurrenceTooLong = fmt.Errorf("the recurrence has more than %d repetitions, a limit is required", MaxExpandedOccurrences)
+
+	errIntervalParts         = errors.New("an interval requires two parts separated by /")
+	errIntervalTwoDurations  = errors.New("an interval cannot be made of two durations")
+	errIntervalNegative      = errors.New("an interval cannot have a negative duration")
+	errIntervalEndBeforeTime = errors.New("the end of an interval cannot be before its start")
+)
+
+// intervalTimeLayouts are the layouts accepted for the start and end of an interval, from the most to the least precise.
+var intervalTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// ISOInterval represents an ISO 8601 time interval, expressed as a start and an end, a start and a duration or a duration and an end.
+type ISOInterval struct {
+	start       *time.Time
+	end         *time.Time
+	duration    *ISODuration
+	startLayout string
+	endLayout   string
+}
+
+// NewISOInterval creates a new ISOInterval from a start and an end.
+func NewISOInterval(start time.Time, end time.Time) (*ISOInterval, error) {
+	if end.Before(start) {
+		return nil, errIntervalEndBeforeTime
+	}
+	return &ISOInterval{
+		start:       &start,
+		end:         &end,
+		startLayout: time.RFC3339Nano,
+		endLayout:   time.RFC3339Nano,
+	}, nil
+}
+
+// NewISOIntervalWithDuration creates a new ISOInterval from a start and a duration.
+func NewISOIntervalWithDuration(start time.Time, duration ISODuration) (*ISOInterval, error) {
+	if duration.IsNegative() && !duration.IsZero() {
+		return nil, errIntervalNegative
+	}
+	return &ISOInterval{
+		start:       &start,
+		duration:    &duration,
+		startLayout: time.RFC3339Nano,
+	}, nil
+}
+
+// NewISOIntervalEndingWithDuration creates a new ISOInterval from a duration and an end.
+func NewISOIntervalEndingWithDuration(duration ISODuration, end time.Time) (*ISOInterval, error) {
+	if duration.IsNegative() && !duration.IsZero() {
+		return nil, errIntervalNegative
+	}
+	return &ISOInterval{
+		end:       &end,
+		duration:  &duration,
+		endLayout: time.RFC3339Nano,
+	}, nil
+}
+
+// ParseISOInterval parses a string into an ISOInterval following the ISO 8601 standard, e.g. 2024-01-01/P1M.
+func ParseISOInterval(s string) (*ISOInterval, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errIntervalParts
+	}
+	startIsDuration := isDurationText(parts[0])
+	endIsDuration := isDurationText(parts[1])
+	switch {
+	case startIsDuration && endIsDuration:
+		return nil, errIntervalTwoDurations
+	case startIsDuration:
+		duration, err := ParseISODuration(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		end, layout, err := parseIntervalTime(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		result, err := NewISOIntervalEndingWithDuration(*duration, end)
+		if err != nil {
+			return nil, err
+		}
+		result.endLayout = layout
+		return result, nil
+	case endIsDuration:
+		start, layout, err := parseIntervalTime(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		duration, err := ParseISODuration(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		result, err := NewISOIntervalWithDuration(start, *duration)
+		if err != nil {
+			return nil, err
+		}
+		result.startLayout = layout
+		return result, nil
+	default:
+		start, startLayout, err := parseIntervalTime(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		end, endLayout, err := parseIntervalTime(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		result, err := NewISOInterval(start, end)
+		if err != nil {
+			return nil, err
+		}
+		result.startLayout = startLayout
+		result.endLayout = endLayout
+		return result, nil
+	}
+}
+
+func isDurationText(s string) bool {
+	return strings.HasPrefix(strings.TrimLeft(s, "+-"), "P")
+}
+
+func parseIntervalTime(s string) (time.Time, string, error) {
+	for _, layout := range intervalTimeLayouts {
+		if value, err := time.Parse(layout, s); err == nil {
+			return value, layout, nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("%q is not a valid ISO 8601 date or date time", s)
+}
+
+// IsZero returns whether the interval is the zero value, which defines neither a start nor an end.
+func (i ISOInterval) IsZero() bool {
+	return i.start == nil && i.end == nil
+}
+
+// GetStart returns the start of the interval, computed from the end and the duration when the interval does not define it.
+// The zero interval returns the zero time.
+func (i ISOInterval) GetStart() time.Time {
+	switch {
+	case i.start != nil:
+		return *i.start
+	case i.end == nil:
+		return time.Time{}
+	case i.duration == nil:
+		return *i.end
+	}
+	return i.duration.Negate().AddTo(*i.end)
+}
+
+// GetEnd returns the end of the interval, computed from the start and the duration when the interval does not define it.
+// The zero interval returns the zero time.
+func (i ISOInterval) GetEnd() time.Time {
+	switch {
+	case i.end != nil:
+		return *i.end
+	case i.start == nil:
+		return time.Time{}
+	case i.duration == nil:
+		return *i.start
+	}
+	return i.duration.AddTo(*i.start)
+}
+
+// GetDuration returns the duration of the interval, computed from the start and the end when the interval does not define it.
+// The zero interval returns the zero duration.
+func (i ISOInterval) GetDuration() ISODuration {
+	if i.duration != nil {
+		return *i.duration
+	}
+	if i.start == nil || i.end == nil {
+		return ISODuration{}
+	}
+	return *Between(*i.start, *i.end)
+}
+
+// HasStart returns whether the interval defines its start explicitly.
+func (i ISOInterval) HasStart() bool {
+	return i.start != nil
+}
+
+// HasEnd returns whether the interval defines its end explicitly.
+func (i ISOInterval) HasEnd() bool {
+	return i.end != nil
+}
+
+// Contains returns whether the time is within the interval, the start is included and the end is excluded.
+// The zero interval contains no time.
+func (i ISOInterval) Contains(t time.Time) bool {
+	if i.IsZero() {
+		return false
+	}
+	return !t.Before(i.GetStart()) && t.Before(i.GetEnd())
+}
+
+// String returns the ISO 8601 representation of the interval, dates and times are written the way they were parsed.
+func (i ISOInterval) String() string {
+	if i.IsZero() {
+		return ""
+	}
+	var start, end string
+	if i.start != nil {
+		start = i.start.Format(i.startLayout)
+	} else {
+		start = i.duration.String()
+	}
+	if i.end != nil {
+		end = i.end.Format(i.endLayout)
+	} else {
+		end = i.duration.String()
+	}
+	return start + "/" + end
+}
+
+// shift returns the interval moved by the number of times its duration.
+func (i ISOInterval) shift(times int) ISOInterval {
+	if times == 0 || i.IsZero() {
+		return i
+	}
+	step := i.GetDuration().scale(times)
+	result := ISOInterval{
+		duration:    i.duration,
+		startLayout: i.startLayout,
+		endLayout:   i.endLayout,
+	}
+	if i.start != nil {
+		start := step.AddTo(*i.start)
+		result.start = &start
+		if i.end != nil {
+			end := i.GetDuration().scale(times + 1).AddTo(*i.start)
+			result.end = &end
+		}
+	} else {
+		end := step.AddTo(*i.end)
+		result.end = &end
+	}
+	return result
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (i ISOInterval) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *ISOInterval) UnmarshalText(text []byte) error {
+	value, err := ParseISOInterval(string(text))
+	if err != nil {
+		return err
+	}
+	*i = *value
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i ISOInterval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, null leaves the value unchanged.
+func (i *ISOInterval) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, i)
+}
@@ -0,0 +1,88 @@
+package serialization
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestItParsesIntervals(t *testing.T) {
+	cases := []struct {
+		input    string
+		start    time.Time
+		end      time.Time
+		duration string
+	}{
+		{"2024-01-01/P1M", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), "P1M"},
+		{"2024-01-01T10:00:00Z/2024-01-02T12:30:00Z", time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 12, 30, 0, 0, time.UTC), "P1DT2H30M"},
+		{"PT2H/2024-01-01T10:00:00+02:00", time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC), "PT2H"},
+		{"2024-01-31T08:00:00/P1M", time.Date(2024, 1, 31, 8, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 8, 0, 0, 0, time.UTC), "P1M"},
+	}
+	for _, c := range cases {
+		interval, err := ParseISOInterval(c.input)
+		assert.Nil(t, err, c.input)
+		assert.True(t, c.start.Equal(interval.GetStart()), c.input)
+		assert.True(t, c.end.Equal(interval.GetEnd()), c.input)
+		duration := interval.GetDuration()
+		assert.Equal(t, c.duration, duration.String(), c.input)
+		assert.Equal(t, c.input, interval.String(), c.input)
+	}
+}
+
+func TestItRefusesInvalidIntervals(t *testing.T) {
+	for _, input := range []string{"", "2024-01-01", "2024-01-01/", "P1D/P2D", "2024-01-02/2024-01-01", "2024-01-01/-P1D", "yesterday/P1D", "2024-01-01/P1D/P1D"} {
+		_, err := ParseISOInterval(input)
+		assert.NotNil(t, err, input)
+	}
+}
+
+func TestIntervalContains(t *testing.T) {
+	interval, err := NewISOIntervalWithDuration(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *NewDuration(0, 0, 1, 0, 0, 0, 0))
+	assert.Nil(t, err)
+
+	assert.True(t, interval.HasStart())
+	assert.False(t, interval.HasEnd())
+	assert.True(t, interval.Contains(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, interval.Contains(time.Date(2024, 1, 1, 23, 59, 0, 0, time.UTC)))
+	assert.False(t, interval.Contains(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2024-01-01T00:00:00Z/P1D", interval.String())
+}
+
+func TestIntervalJson(t *testing.T) {
+	type model struct {
+		Window ISOInterval `json:"window"`
+	}
+	var result model
+	assert.Nil(t, json.Unmarshal([]byte(`{"window":"2024-01-01/P1W"}`), &result))
+	assert.Equal(t, "2024-01-01/P1W", result.Window.String())
+
+	content, err := json.Marshal(result)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"window":"2024-01-01/P1W"}`, string(content))
+}
+
+func TestZeroInterval(t *testing.T) {
+	var interval ISOInterval
+	assert.True(t, interval.IsZero())
+	assert.True(t, interval.GetStart().IsZero())
+	assert.True(t, interval.GetEnd().IsZero())
+	assert.True(t, interval.GetDuration().IsZero())
+	assert.False(t, interval.Contains(time.Now()))
+	assert.Equal(t, "", interval.String())
+
+	assert.Nil(t, json.Unmarshal([]byte(`null`), &interval))
+	assert.True(t, interval.IsZero())
+	assert.True(t, interval.GetStart().IsZero())
+
+	recurrence := NewISORecurrence(3, interval)
+	occurrences, err := recurrence.Expand(0)
+	assert.Nil(t, err)
+	assert.Len(t, occurrences, 3)
+	assert.Empty(t, recurrence.ExpandBetween(time.Now(), time.Now().Add(time.Hour)))
+
+	var zeroRecurrence ISORecurrence
+	assert.True(t, zeroRecurrence.GetInterval().GetEnd().IsZero())
+	assert.Empty(t, zeroRecurrence.ExpandBetween(time.Now(), time.Now().Add(time.Hour)))
+}
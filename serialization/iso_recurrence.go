@@ -0,0 +1,162 @@
+package serialization
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errRecurrenceFormat = errors.New("a recurrence must start with R followed by an optional number of repetitions and an interval, e.g. R5/2024-01-01T00:00:00Z/P1D")
+
+// ISORecurrence represents an ISO 8601 repeating interval, e.g. R5/2024-01-01T00:00:00Z/P1D.
+// Recurrences whose interval only defines an end repeat backwards from that end.
+type ISORecurrence struct {
+	repetitions int
+	interval    ISOInterval
+}
+
+// NewISORecurrence creates a new ISORecurrence repeating the interval the number of times, a negative number of repetitions makes the recurrence unbounded.
+func NewISORecurrence(repetitions int, interval ISOInterval) *ISORecurrence {
+	if repetitions < 0 {
+		repetitions = -1
+	}
+	return &ISORecurrence{
+		repetitions: repetitions,
+		interval:    interval,
+	}
+}
+
+// ParseISORecurrence parses a string into an ISORecurrence following the ISO 8601 standard.
+func ParseISORecurrence(s string) (*ISORecurrence, error) {
+	s = strings.TrimSpace(s)
+	separator := strings.Index(s, "/")
+	if !strings.HasPrefix(s, "R") || separator < 0 {
+		return nil, errRecurrenceFormat
+	}
+	repetitions := -1
+	if count := s[1:separator]; count != "" && count != "-1" {
+		value, err := strconv.Atoi(count)
+		if err != nil || value < 0 || strings.HasPrefix(count, "+") {
+			return nil, errRecurrenceFormat
+		}
+		repetitions = value
+	}
+	interval, err := ParseISOInterval(s[separator+1:])
+	if err != nil {
+		return nil, err
+	}
+	return NewISORecurrence(repetitions, *interval), nil
+}
+
+// GetRepetitions returns the number of repetitions, -1 when the recurrence is unbounded.
+func (r ISORecurrence) GetRepetitions() int {
+	return r.repetitions
+}
+
+// IsUnbounded returns whether the recurrence repeats indefinitely.
+func (r ISORecurrence) IsUnbounded() bool {
+	return r.repetitions < 0
+}
+
+// GetInterval returns the interval being repeated.
+func (r ISORecurrence) GetInterval() ISOInterval {
+	return r.interval
+}
+
+// String returns the ISO 8601 representation of the recurrence.
+func (r ISORecurrence) String() string {
+	count := ""
+	if !r.IsUnbounded() {
+		count = strconv.Itoa(r.repetitions)
+	}
+	return "R" + count + "/" + r.interval.String()
+}
+
+// occurrence returns the nth occurrence, counting backwards from the end when the interval does not define its start.
+func (r ISORecurrence) occurrence(n int) ISOInterval {
+	if r.interval.HasStart() {
+		return r.interval.shift(n)
+	}
+	return r.interval.shift(-n)
+}
+
+// MaxExpandedOccurrences is the largest number of occurrences Expand returns when no limit is given.
+const MaxExpandedOccurrences = 100000
+
+// Expand returns the occurrences of the recurrence in chronological order, limit caps the number of occurrences and is required for unbounded recurrences
+// and for recurrences with more than MaxExpandedOccurrences repetitions.
+func (r ISORecurrence) Expand(limit int) ([]ISOInterval, error) {
+	count := r.repetitions
+	if limit <= 0 {
+		if r.IsUnbounded() {
+			return nil, ErrUnboundedRecurrence
+		}
+		if count > MaxExpandedOccurrences {
+			return nil, ErrRecurrenceTooLong
+		}
+	} else if r.IsUnbounded() || limit < count {
+		count = limit
+	}
+	// the number of repetitions may come from untrusted text, so the capacity is bounded and the result grows past it
+	result := make([]ISOInterval, 0, min(count, MaxExpandedOccurrences))
+	for n := 0; n < count; n++ {
+		result = append(result, r.occurrence(n))
+	}
+	if !r.interval.HasStart() {
+		slices.Reverse(result)
+	}
+	return result, nil
+}
+
+// ExpandBetween returns the occurrences starting between from, included, and to, excluded, in chronological order.
+func (r ISORecurrence) ExpandBetween(from time.Time, to time.Time) []ISOInterval {
+	result := make([]ISOInterval, 0)
+	forward := r.interval.HasStart()
+	// a zero duration would repeat the same occurrence forever
+	count := r.repetitions
+	if r.interval.GetDuration().IsZero() && (count < 0 || count > 1) {
+		count = 1
+	}
+	for n := 0; count < 0 || n < count; n++ {
+		occurrence := r.occurrence(n)
+		start := occurrence.GetStart()
+		if forward && !start.Before(to) || !forward && start.Before(from) {
+			break
+		}
+		if !start.Before(from) && start.Before(to) {
+			result = append(result, occurrence)
+		}
+	}
+	if !forward {
+		slices.Reverse(result)
+	}
+	return result
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r ISORecurrence) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *ISORecurrence) UnmarshalText(text []byte) error {
+	value, err := ParseISORecurrence(string(text))
+	if err != nil {
+		return err
+	}
+	*r = *value
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r ISORecurrence) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, null leaves the value unchanged.
+func (r *ISORecurrence) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, r)
+}
@@ -0,0 +1,109 @@
+package serialization
+
+import (
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func intervalStarts(intervals []ISOInterval) []string {
+	result := make([]string, len(intervals))
+	for i, interval := range intervals {
+		result[i] = interval.GetStart().Format(time.RFC3339)
+	}
+	return result
+}
+
+func TestItParsesRecurrences(t *testing.T) {
+	recurrence, err := ParseISORecurrence("R5/2024-01-01T00:00:00Z/P1D")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, recurrence.GetRepetitions())
+	assert.False(t, recurrence.IsUnbounded())
+	assert.Equal(t, "2024-01-01T00:00:00Z/P1D", recurrence.GetInterval().String())
+	assert.Equal(t, "R5/2024-01-01T00:00:00Z/P1D", recurrence.String())
+
+	recurrence, err = ParseISORecurrence("R/2024-01-01/P1W")
+	assert.Nil(t, err)
+	assert.True(t, recurrence.IsUnbounded())
+	assert.Equal(t, -1, recurrence.GetRepetitions())
+	assert.Equal(t, "R/2024-01-01/P1W", recurrence.String())
+}
+
+func TestItRefusesInvalidRecurrences(t *testing.T) {
+	for _, input := range []string{"", "R5", "5/2024-01-01/P1D", "Rx/2024-01-01/P1D", "R-2/2024-01-01/P1D", "R5/2024-01-01"} {
+		_, err := ParseISORecurrence(input)
+		assert.NotNil(t, err, input)
+	}
+}
+
+func TestItExpandsRecurrences(t *testing.T) {
+	recurrence, _ := ParseISORecurrence("R3/2024-01-31T09:00:00Z/P1M")
+
+	occurrences, err := recurrence.Expand(0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2024-01-31T09:00:00Z", "2024-02-29T09:00:00Z", "2024-03-31T09:00:00Z"}, intervalStarts(occurrences))
+	assert.Equal(t, time.Date(2024, 3, 29, 9, 0, 0, 0, time.UTC), occurrences[1].GetEnd())
+
+	occurrences, err = recurrence.Expand(2)
+	assert.Nil(t, err)
+	assert.Len(t, occurrences, 2)
+}
+
+func TestItExpandsRecurrencesWithAStartAndAnEnd(t *testing.T) {
+	recurrence, _ := ParseISORecurrence("R2/2024-01-01T09:00:00Z/2024-01-01T10:30:00Z")
+
+	occurrences, err := recurrence.Expand(0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2024-01-01T09:00:00Z", "2024-01-01T10:30:00Z"}, intervalStarts(occurrences))
+	assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), occurrences[1].GetEnd())
+}
+
+func TestItExpandsRecurrencesBackwardsFromTheEnd(t *testing.T) {
+	recurrence, _ := ParseISORecurrence("R3/P1D/2024-01-10T00:00:00Z")
+
+	occurrences, err := recurrence.Expand(0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2024-01-07T00:00:00Z", "2024-01-08T00:00:00Z", "2024-01-09T00:00:00Z"}, intervalStarts(occurrences))
+}
+
+func TestItRequiresALimitForUnboundedRecurrences(t *testing.T) {
+	recurrence, _ := ParseISORecurrence("R/2024-01-01T00:00:00Z/PT8H")
+
+	_, err := recurrence.Expand(0)
+	assert.Equal(t, ErrUnboundedRecurrence, err)
+
+	occurrences, err := recurrence.Expand(4)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2024-01-01T00:00:00Z", "2024-01-01T08:00:00Z", "2024-01-01T16:00:00Z", "2024-01-02T00:00:00Z"}, intervalStarts(occurrences))
+}
+
+func TestItRequiresALimitForLongRecurrences(t *testing.T) {
+	recurrence, err := ParseISORecurrence("R99999999999999/2024-01-01T00:00:00Z/P1D")
+	assert.Nil(t, err)
+
+	_, err = recurrence.Expand(0)
+	assert.Equal(t, ErrRecurrenceTooLong, err)
+
+	occurrences, err := recurrence.Expand(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2024-01-01T00:00:00Z", "2024-01-02T00:00:00Z"}, intervalStarts(occurrences))
+}
+
+func TestItExpandsRecurrencesBetweenDates(t *testing.T) {
+	unbounded, _ := ParseISORecurrence("R/2024-01-01T00:00:00Z/P1W")
+	occurrences := unbounded.ExpandBetween(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, []string{"2024-01-15T00:00:00Z", "2024-01-22T00:00:00Z"}, intervalStarts(occurrences))
+
+	bounded, _ := ParseISORecurrence("R2/2024-01-01T00:00:00Z/P1W")
+	occurrences = bounded.ExpandBetween(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, []string{"2024-01-01T00:00:00Z", "2024-01-08T00:00:00Z"}, intervalStarts(occurrences))
+
+	backwards, _ := ParseISORecurrence("R/P1D/2024-01-10T00:00:00Z")
+	occurrences = backwards.ExpandBetween(time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, []string{"2024-01-07T00:00:00Z", "2024-01-08T00:00:00Z"}, intervalStarts(occurrences))
+
+	zero, _ := ParseISORecurrence("R/2024-01-01T00:00:00Z/PT0S")
+	occurrences = zero.ExpandBetween(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, occurrences, 1)
+}
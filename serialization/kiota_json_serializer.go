@@ -1,5 +1,10 @@
 package serialization
 
+import (
+	"io"
+	"iter"
+)
+
 var jsonContentType = "application/json"
 
 // SerializeToJson serializes the given model to JSON
@@ -12,6 +17,16 @@ func SerializeCollectionToJson(models []Parsable) ([]byte, error) {
 	return SerializeCollection(jsonContentType, models)
 }
 
+// SerializeToJsonWriter serializes the given model to JSON into w
+func SerializeToJsonWriter(w io.Writer, model Parsable) error {
+	return SerializeTo(w, jsonContentType, model)
+}
+
+// SerializeCollectionToJsonWriter serializes the given models to JSON into w
+func SerializeCollectionToJsonWriter(w io.Writer, models []Parsable) error {
+	return SerializeCollectionTo(w, jsonContentType, models)
+}
+
 // DeserializeFromJson deserializes the given JSON to a model
 func DeserializeFromJson(content []byte, parsableFactory ParsableFactory) (Parsable, error) {
 	return Deserialize(jsonContentType, content, parsableFactory)
@@ -21,3 +36,8 @@ func DeserializeFromJson(content []byte, parsableFactory ParsableFactory) (Parsa
 func DeserializeCollectionFromJson(content []byte, parsableFactory ParsableFactory) ([]Parsable, error) {
 	return DeserializeCollection(jsonContentType, content, parsableFactory)
 }
+
+// DeserializeCollectionFromJsonReader deserializes the JSON read from the reader to a sequence of models
+func DeserializeCollectionFromJsonReader(content io.Reader, parsableFactory ParsableFactory) iter.Seq2[Parsable, error] {
+	return DeserializeCollectionFrom(jsonContentType, content, parsableFactory)
+}
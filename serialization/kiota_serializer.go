@@ -2,6 +2,8 @@ package serialization
 
 import (
 	"errors"
+	"io"
+	"iter"
 )
 
 // Serialize serializes the given model into a byte array.
@@ -31,6 +33,46 @@ func SerializeCollection(contentType string, models []Parsable) ([]byte, error)
 	}
 	return writer.GetSerializedContent()
 }
+
+// SerializeTo serializes the given model into w as the content is produced.
+func SerializeTo(w io.Writer, contentType string, model Parsable) error {
+	writer, err := getStreamSerializationWriter(w, contentType, model)
+	if err != nil {
+		return err
+	}
+	err = writer.WriteObjectValue("", model)
+	if err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+// SerializeCollectionTo serializes the given models into w as the content is produced.
+func SerializeCollectionTo(w io.Writer, contentType string, models []Parsable) error {
+	writer, err := getStreamSerializationWriter(w, contentType, models)
+	if err != nil {
+		return err
+	}
+	err = writer.WriteCollectionOfObjectValues("", models)
+	if err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+func getStreamSerializationWriter(w io.Writer, contentType string, value interface{}) (SerializationWriter, error) {
+	if w == nil {
+		return nil, errors.New("the io.Writer is empty")
+	}
+	if contentType == "" {
+		return nil, errors.New("the content type is empty")
+	}
+	if value == nil {
+		return nil, errors.New("the value is empty")
+	}
+	return DefaultSerializationWriterFactoryInstance.GetStreamSerializationWriter(contentType, w)
+}
 func getSerializationWriter(contentType string, value interface{}) (SerializationWriter, error) {
 	if contentType == "" {
 		return nil, errors.New("the content type is empty")
@@ -87,3 +129,32 @@ func DeserializeCollection(contentType string, content []byte, parsableFactory P
 	}
 	return result, nil
 }
+
+// DeserializeCollectionFrom deserializes the content read from the reader into a sequence of models yielded one at a time.
+// The content is only read as the sequence is iterated, iteration stops after the first error is yielded.
+func DeserializeCollectionFrom(contentType string, content io.Reader, parsableFactory ParsableFactory) iter.Seq2[Parsable, error] {
+	return func(yield func(Parsable, error) bool) {
+		if contentType == "" {
+			yield(nil, errors.New("the content type is empty"))
+			return
+		}
+		if content == nil {
+			yield(nil, errors.New("the content is empty"))
+			return
+		}
+		if parsableFactory == nil {
+			yield(nil, errors.New("the parsable factory is empty"))
+			return
+		}
+		node, err := DefaultParseNodeFactoryInstance.GetRootParseNodeFromReader(contentType, content)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for item, err := range IterateCollectionOfObjectValues(node, parsableFactory) {
+			if !yield(item, err) || err != nil {
+				return
+			}
+		}
+	}
+}
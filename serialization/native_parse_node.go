@@ -0,0 +1,575 @@
+package serialization
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NativeParseNode is a ParseNode implementation backed by native Go values, map[string]any for objects, []any for collections and primitive values.
+// Primitive getters accept both the typed value and its string representation.
+type NativeParseNode struct {
+	value                     any
+	onBeforeAssignFieldValues ParsableAction
+	onAfterAssignFieldValues  ParsableAction
+}
+
+// NewNativeParseNode creates a new NativeParseNode for the given native value.
+func NewNativeParseNode(value any) *NativeParseNode {
+	return &NativeParseNode{value: dereferenceNative(value)}
+}
+
+// FromNative hydrates a model from a tree of native Go values.
+func FromNative(value any, ctor ParsableFactory) (Parsable, error) {
+	return NewNativeParseNode(value).GetObjectValue(ctor)
+}
+
+// dereferenceNative returns the value pointed to by pointers and interfaces, nil pointers return nil.
+// Untyped nodes are replaced by their plain Go values.
+func dereferenceNative(value any) any {
+	for value != nil {
+		reflected := reflect.ValueOf(value)
+		if reflected.Kind() != reflect.Pointer && reflected.Kind() != reflect.Interface {
+			return value
+		}
+		if reflected.IsNil() {
+			return nil
+		}
+		switch v := value.(type) {
+		case UntypedNodeable:
+			// nodes failing to convert are kept and rejected when read
+			if converted, err := UntypedToValue(v); err == nil {
+				return dereferenceNative(converted)
+			}
+			return value
+		case Parsable:
+			return value
+		}
+		value = reflected.Elem().Interface()
+	}
+	return nil
+}
+
+func (n *NativeParseNode) newChildNode(value any) *NativeParseNode {
+	child := NewNativeParseNode(value)
+	child.onBeforeAssignFieldValues = n.onBeforeAssignFieldValues
+	child.onAfterAssignFieldValues = n.onAfterAssignFieldValues
+	return child
+}
+
+// objectEntries returns the entries of the node when it holds a map with string keys.
+func (n *NativeParseNode) objectEntries() (map[string]any, bool) {
+	if n.value == nil {
+		return nil, false
+	}
+	if entries, ok := n.value.(map[string]any); ok {
+		return entries, true
+	}
+	reflected := reflect.ValueOf(n.value)
+	if reflected.Kind() != reflect.Map || reflected.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	entries := make(map[string]any, reflected.Len())
+	iterator := reflected.MapRange()
+	for iterator.Next() {
+		entries[iterator.Key().String()] = iterator.Value().Interface()
+	}
+	return entries, true
+}
+
+// collectionItems returns the items of the node when it holds a slice or an array.
+func (n *NativeParseNode) collectionItems() ([]any, bool) {
+	if n.value == nil {
+		return nil, false
+	}
+	if items, ok := n.value.([]any); ok {
+		return items, true
+	}
+	if _, ok := n.value.([]byte); ok {
+		return nil, false
+	}
+	reflected := reflect.ValueOf(n.value)
+	if reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]any, reflected.Len())
+	for i := range items {
+		items[i] = reflected.Index(i).Interface()
+	}
+	return items, true
+}
+
+// GetChildNode returns a new parse node for the given identifier.
+func (n *NativeParseNode) GetChildNode(index string) (ParseNode, error) {
+	if index == "" {
+		return nil, errors.New("index is empty")
+	}
+	entries, ok := n.objectEntries()
+	if !ok {
+		return nil, nil
+	}
+	value, ok := entries[index]
+	if !ok {
+		return nil, nil
+	}
+	return n.newChildNode(value), nil
+}
+
+// GetObjectValue returns the Parsable value from the node.
+func (n *NativeParseNode) GetObjectValue(ctor ParsableFactory) (Parsable, error) {
+	if ctor == nil {
+		return nil, errors.New("constructor is nil")
+	}
+	if n.value == nil {
+		return nil, nil
+	}
+	result, err := ctor(n)
+	if err != nil {
+		return nil, err
+	}
+	if isNilParsable(result) {
+		return nil, errors.New("the constructor returned a nil value")
+	}
+	if _, isUntypedNode := result.(UntypedNodeable); isUntypedNode {
+		// untyped nodes are built from the value whatever its shape
+		return UntypedFromValue(n.value)
+	}
+	entries, ok := n.objectEntries()
+	if !ok {
+		return nil, fmt.Errorf("value of type %T cannot be deserialized as an object", n.value)
+	}
+	if n.onBeforeAssignFieldValues != nil {
+		if err := n.onBeforeAssignFieldValues(result); err != nil {
+			return nil, err
+		}
+	}
+	fields := result.GetFieldDeserializers()
+	holder, isHolder := result.(AdditionalDataHolder)
+	for key, value := range entries {
+		field := fields[key]
+		if field == nil {
+			if isHolder {
+				additionalData := holder.GetAdditionalData()
+				if additionalData == nil {
+					additionalData = make(map[string]any)
+					holder.SetAdditionalData(additionalData)
+				}
+				additionalData[key] = value
+			}
+			continue
+		}
+		if err := field(n.newChildNode(value)); err != nil {
+			return nil, err
+		}
+	}
+	if n.onAfterAssignFieldValues != nil {
+		if err := n.onAfterAssignFieldValues(result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// GetCollectionOfObjectValues returns the collection of Parsable values from the node.
+func (n *NativeParseNode) GetCollectionOfObjectValues(ctor ParsableFactory) ([]Parsable, error) {
+	if ctor == nil {
+		return nil, errors.New("constructor is nil")
+	}
+	if n.value == nil {
+		return nil, nil
+	}
+	items, ok := n.collectionItems()
+	if !ok {
+		return nil, fmt.Errorf("value of type %T cannot be deserialized as a collection", n.value)
+	}
+	result := make([]Parsable, len(items))
+	for i, item := range items {
+		value, err := n.newChildNode(item).GetObjectValue(ctor)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = value
+	}
+	return result, nil
+}
+
+// GetCollectionOfPrimitiveValues returns the collection of primitive values from the node.
+func (n *NativeParseNode) GetCollectionOfPrimitiveValues(targetType string) ([]interface{}, error) {
+	if targetType == "" {
+		return nil, errors.New("targetType is empty")
+	}
+	if n.value == nil {
+		return nil, nil
+	}
+	items, ok := n.collectionItems()
+	if !ok {
+		return nil, fmt.Errorf("value of type %T cannot be deserialized as a collection", n.value)
+	}
+	result := make([]interface{}, len(items))
+	for i, item := range items {
+		node := n.newChildNode(item)
+		var val interface{}
+		var err error
+		switch targetType {
+		case "string":
+			val, err = node.GetStringValue()
+		case "bool":
+			val, err = node.GetBoolValue()
+		case "uint8":
+			val, err = node.GetInt8Value()
+		case "byte":
+			val, err = node.GetByteValue()
+		case "float32":
+			val, err = node.GetFloat32Value()
+		case "float64":
+			val, err = node.GetFloat64Value()
+		case "int32":
+			val, err = node.GetInt32Value()
+		case "int64":
+			val, err = node.GetInt64Value()
+		case "time":
+			val, err = node.GetTimeValue()
+		case "timeonly":
+			val, err = node.GetTimeOnlyValue()
+		case "dateonly":
+			val, err = node.GetDateOnlyValue()
+		case "isoduration":
+			val, err = node.GetISODurationValue()
+		case "uuid":
+			val, err = node.GetUUIDValue()
+		case "base64":
+			val, err = node.GetByteArrayValue()
+		default:
+			return nil, fmt.Errorf("targetType %s is not supported", targetType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result[i] = val
+	}
+	return result, nil
+}
+
+// GetCollectionOfEnumValues returns the collection of Enum values from the node.
+func (n *NativeParseNode) GetCollectionOfEnumValues(parser EnumFactory) ([]interface{}, error) {
+	if parser == nil {
+		return nil, errors.New("parser is nil")
+	}
+	if n.value == nil {
+		return nil, nil
+	}
+	items, ok := n.collectionItems()
+	if !ok {
+		return nil, fmt.Errorf("value of type %T cannot be deserialized as a collection", n.value)
+	}
+	result := make([]interface{}, len(items))
+	for i, item := range items {
+		value, err := n.newChildNode(item).GetEnumValue(parser)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = value
+	}
+	return result, nil
+}
+
+// nativeString returns the node value when it is a string or a fmt.Stringer.
+func (n *NativeParseNode) nativeString() (string, bool) {
+	switch v := n.value.(type) {
+	case string:
+		return v, true
+	case fmt.Stringer:
+		return v.String(), true
+	}
+	reflected := reflect.ValueOf(n.value)
+	if reflected.Kind() == reflect.String {
+		return reflected.String(), true
+	}
+	return "", false
+}
+
+func (n *NativeParseNode) errUnexpectedType(target string) error {
+	return fmt.Errorf("value of type %T cannot be converted to %s", n.value, target)
+}
+
+// GetStringValue returns a String value from the nodes.
+func (n *NativeParseNode) GetStringValue() (*string, error) {
+	if n.value == nil {
+		return nil, nil
+	}
+	if value, ok := n.nativeString(); ok {
+		return &value, nil
+	}
+	return nil, n.errUnexpectedType("string")
+}
+
+// GetBoolValue returns a Bool value from the nodes.
+func (n *NativeParseNode) GetBoolValue() (*bool, error) {
+	if n.value == nil {
+		return nil, nil
+	}
+	if value, ok := n.value.(bool); ok {
+		return &value, nil
+	}
+	if value, ok := n.nativeString(); ok {
+		result, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+	return nil, n.errUnexpectedType("bool")
+}
+
+// nativeInteger converts the node value into an integer within the given bounds.
+func (n *NativeParseNode) nativeInteger(minValue int64, maxValue int64, target string) (*int64, error) {
+	if n.value == nil {
+		return nil, nil
+	}
+	var result int64
+	reflected := reflect.ValueOf(n.value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result = reflected.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if reflected.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("value %d overflows %s", reflected.Uint(), target)
+		}
+		result = int64(reflected.Uint())
+	case reflect.Float32, reflect.Float64:
+		value := reflected.Float()
+		if value != math.Trunc(value) || value < math.MinInt64 || value >= math.MaxInt64 {
+			return nil, fmt.Errorf("value %v cannot be converted to %s", value, target)
+		}
+		result = int64(value)
+	case reflect.String:
+		value, err := strconv.ParseInt(reflected.String(), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		result = value
+	default:
+		return nil, n.errUnexpectedType(target)
+	}
+	if result < minValue || result > maxValue {
+		return nil, fmt.Errorf("value %d overflows %s", result, target)
+	}
+	return &result, nil
+}
+
+// nativeFloat converts the node value into a float.
+func (n *NativeParseNode) nativeFloat(bitSize int, target string) (*float64, error) {
+	if n.value == nil {
+		return nil, nil
+	}
+	var result float64
+	reflected := reflect.ValueOf(n.value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result = float64(reflected.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		result = float64(reflected.Uint())
+	case reflect.Float32, reflect.Float64:
+		result = reflected.Float()
+	case reflect.String:
+		value, err := strconv.ParseFloat(reflected.String(), bitSize)
+		if err != nil {
+			return nil, err
+		}
+		result = value
+	default:
+		return nil, n.errUnexpectedType(target)
+	}
+	return &result, nil
+}
+
+func castNative[T any, R any](value *T, err error, cast func(T) R) (*R, error) {
+	if value == nil || err != nil {
+		return nil, err
+	}
+	result := cast(*value)
+	return &result, nil
+}
+
+// GetInt8Value returns a int8 value from the nodes.
+func (n *NativeParseNode) GetInt8Value() (*int8, error) {
+	value, err := n.nativeInteger(math.MinInt8, math.MaxInt8, "int8")
+	return castNative(value, err, func(v int64) int8 { return int8(v) })
+}
+
+// GetByteValue returns a Byte value from the nodes.
+func (n *NativeParseNode) GetByteValue() (*byte, error) {
+	value, err := n.nativeInteger(0, math.MaxUint8, "byte")
+	return castNative(value, err, func(v int64) byte { return byte(v) })
+}
+
+// GetInt32Value returns a int32 value from the nodes.
+func (n *NativeParseNode) GetInt32Value() (*int32, error) {
+	value, err := n.nativeInteger(math.MinInt32, math.MaxInt32, "int32")
+	return castNative(value, err, func(v int64) int32 { return int32(v) })
+}
+
+// GetInt64Value returns a int64 value from the nodes.
+func (n *NativeParseNode) GetInt64Value() (*int64, error) {
+	return n.nativeInteger(math.MinInt64, math.MaxInt64, "int64")
+}
+
+// GetFloat32Value returns a Float32 value from the nodes.
+func (n *NativeParseNode) GetFloat32Value() (*float32, error) {
+	value, err := n.nativeFloat(32, "float32")
+	return castNative(value, err, func(v float64) float32 { return float32(v) })
+}
+
+// GetFloat64Value returns a Float64 value from the nodes.
+func (n *NativeParseNode) GetFloat64Value() (*float64, error) {
+	return n.nativeFloat(64, "float64")
+}
+
+// GetTimeValue returns a Time value from the nodes.
+func (n *NativeParseNode) GetTimeValue() (*time.Time, error) {
+	if n.value == nil {
+		return nil, nil
+	}
+	if value, ok := n.value.(time.Time); ok {
+		return &value, nil
+	}
+	if value, ok := n.nativeString(); ok {
+		result, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+	return nil, n.errUnexpectedType("time")
+}
+
+// GetISODurationValue returns a ISODuration value from the nodes.
+func (n *NativeParseNode) GetISODurationValue() (*ISODuration, error) {
+	switch v := n.value.(type) {
+	case nil:
+		return nil, nil
+	case ISODuration:
+		return &v, nil
+	case time.Duration:
+		return FromDuration(v), nil
+	}
+	if value, ok := n.nativeString(); ok {
+		return ParseISODuration(value)
+	}
+	return nil, n.errUnexpectedType("ISODuration")
+}
+
+// GetTimeOnlyValue returns a TimeOnly value from the nodes.
+func (n *NativeParseNode) GetTimeOnlyValue() (*TimeOnly, error) {
+	switch v := n.value.(type) {
+	case nil:
+		return nil, nil
+	case TimeOnly:
+		return &v, nil
+	case time.Time:
+		return NewTimeOnly(v), nil
+	}
+	if value, ok := n.nativeString(); ok {
+		return ParseTimeOnly(value)
+	}
+	return nil, n.errUnexpectedType("TimeOnly")
+}
+
+// GetDateOnlyValue returns a DateOnly value from the nodes.
+func (n *NativeParseNode) GetDateOnlyValue() (*DateOnly, error) {
+	switch v := n.value.(type) {
+	case nil:
+		return nil, nil
+	case DateOnly:
+		return &v, nil
+	case time.Time:
+		return NewDateOnly(v), nil
+	}
+	if value, ok := n.nativeString(); ok {
+		return ParseDateOnly(value)
+	}
+	return nil, n.errUnexpectedType("DateOnly")
+}
+
+// GetUUIDValue returns a UUID value from the nodes.
+func (n *NativeParseNode) GetUUIDValue() (*uuid.UUID, error) {
+	switch v := n.value.(type) {
+	case nil:
+		return nil, nil
+	case uuid.UUID:
+		return &v, nil
+	}
+	if value, ok := n.nativeString(); ok {
+		result, err := uuid.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+	return nil, n.errUnexpectedType("UUID")
+}
+
+// GetEnumValue returns a Enum value from the nodes.
+func (n *NativeParseNode) GetEnumValue(parser EnumFactory) (interface{}, error) {
+	if parser == nil {
+		return nil, errors.New("parser is nil")
+	}
+	if n.value == nil {
+		return nil, nil
+	}
+	value, ok := n.nativeString()
+	if !ok {
+		return nil, n.errUnexpectedType("enum")
+	}
+	if value == "" {
+		return nil, nil
+	}
+	return parser(value)
+}
+
+// GetByteArrayValue returns a ByteArray value from the nodes.
+// Strings are decoded from base64.
+func (n *NativeParseNode) GetByteArrayValue() ([]byte, error) {
+	switch v := n.value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return base64.StdEncoding.DecodeString(v)
+	}
+	return nil, n.errUnexpectedType("byte array")
+}
+
+// GetRawValue returns the values of the node as an interface of any type.
+func (n *NativeParseNode) GetRawValue() (interface{}, error) {
+	return n.value, nil
+}
+
+// GetOnBeforeAssignFieldValues returns a callback invoked before the node is deserialized.
+func (n *NativeParseNode) GetOnBeforeAssignFieldValues() ParsableAction {
+	return n.onBeforeAssignFieldValues
+}
+
+// SetOnBeforeAssignFieldValues sets a callback invoked before the node is deserialized.
+func (n *NativeParseNode) SetOnBeforeAssignFieldValues(action ParsableAction) error {
+	n.onBeforeAssignFieldValues = action
+	return nil
+}
+
+// GetOnAfterAssignFieldValues returns a callback invoked after the node is deserialized.
+func (n *NativeParseNode) GetOnAfterAssignFieldValues() ParsableAction {
+	return n.onAfterAssignFieldValues
+}
+
+// SetOnAfterAssignFieldValues sets a callback invoked after the node is deserialized.
+func (n *NativeParseNode) SetOnAfterAssignFieldValues(action ParsableAction) error {
+	n.onAfterAssignFieldValues = action
+	return nil
+}
@@ -0,0 +1,122 @@
+package serialization
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestNativeParseNodeHonoursInterface(t *testing.T) {
+	assert.Implements(t, (*ParseNode)(nil), NewNativeParseNode(nil))
+}
+
+func TestFromNativeRoundTripsToNative(t *testing.T) {
+	name := "parent"
+	childName := "child"
+	count := int32(2)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	model := &nativeTestModel{
+		name:           &name,
+		count:          &count,
+		tags:           []string{"a", "b"},
+		createdAt:      &createdAt,
+		birthday:       NewDateOnly(createdAt),
+		child:          &nativeTestModel{name: &childName},
+		children:       []*nativeTestModel{{name: &childName}},
+		additionalData: map[string]any{"extra": "value"},
+	}
+	native, err := ToNative(model)
+	assert.Nil(t, err)
+	result, err := FromNative(native, createNativeTestModelFromDiscriminatorValue)
+	assert.Nil(t, err)
+	assert.Equal(t, model, result)
+}
+
+func TestNativeParseNodeReadsDecodedJson(t *testing.T) {
+	var value any
+	err := json.Unmarshal([]byte(`{"name":"parent","count":3,"tags":["a"],"createdAt":"2024-01-02T03:04:05Z","birthday":"2024-01-02","children":[{"name":"child"},null],"extra":{"a":1}}`), &value)
+	assert.Nil(t, err)
+	result, err := FromNative(value, createNativeTestModelFromDiscriminatorValue)
+	assert.Nil(t, err)
+	model := result.(*nativeTestModel)
+	assert.Equal(t, "parent", *model.name)
+	assert.Equal(t, int32(3), *model.count)
+	assert.Equal(t, []string{"a"}, model.tags)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), *model.createdAt)
+	assert.Equal(t, "2024-01-02", model.birthday.String())
+	assert.Equal(t, "child", *model.children[0].name)
+	assert.Nil(t, model.children[1])
+	assert.Equal(t, map[string]any{"extra": map[string]any{"a": float64(1)}}, model.additionalData)
+}
+
+func TestNativeParseNodeConvertsNumbers(t *testing.T) {
+	value, err := NewNativeParseNode(float64(42)).GetInt32Value()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(42), *value)
+
+	_, err = NewNativeParseNode(1.5).GetInt64Value()
+	assert.NotNil(t, err)
+
+	_, err = NewNativeParseNode(300).GetByteValue()
+	assert.NotNil(t, err)
+
+	floatValue, err := NewNativeParseNode("1.25").GetFloat64Value()
+	assert.Nil(t, err)
+	assert.Equal(t, 1.25, *floatValue)
+
+	_, err = NewNativeParseNode(true).GetStringValue()
+	assert.NotNil(t, err)
+}
+
+func TestNativeParseNodeRejectsUnexpectedShapes(t *testing.T) {
+	_, err := NewNativeParseNode("text").GetObjectValue(createNativeTestModelFromDiscriminatorValue)
+	assert.NotNil(t, err)
+	_, err = NewNativeParseNode(map[string]any{}).GetCollectionOfObjectValues(createNativeTestModelFromDiscriminatorValue)
+	assert.NotNil(t, err)
+}
+
+func TestNativeParseNodeRejectsNilModels(t *testing.T) {
+	_, err := NewNativeParseNode(map[string]any{"name": "parent"}).GetObjectValue(func(ParseNode) (Parsable, error) {
+		return nil, nil
+	})
+	assert.NotNil(t, err)
+	_, err = NewNativeParseNode(map[string]any{"name": "parent"}).GetObjectValue(func(ParseNode) (Parsable, error) {
+		return (*nativeTestModel)(nil), nil
+	})
+	assert.NotNil(t, err)
+}
+
+func TestNativeParseNodeBuildsUntypedNodes(t *testing.T) {
+	result, err := NewNativeParseNode(map[string]any{"name": "parent", "tags": []any{"a", nil}}).GetObjectValue(CreateUntypedNodeFromDiscriminatorValue)
+	assert.Nil(t, err)
+	value, err := UntypedToValue(result.(UntypedNodeable))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"name": "parent", "tags": []any{"a", nil}}, value)
+
+	result, err = NewNativeParseNode("text").GetObjectValue(CreateUntypedNodeFromDiscriminatorValue)
+	assert.Nil(t, err)
+	assert.Equal(t, "text", *result.(*UntypedString).GetValue())
+
+	items, err := NewNativeParseNode([]any{map[string]any{"id": "1"}, true}).GetCollectionOfObjectValues(CreateUntypedNodeFromDiscriminatorValue)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"id": "1"}, items[0].(*UntypedObject).ToValue())
+	assert.Equal(t, true, items[1].(*UntypedBoolean).ToValue())
+}
+
+func TestNativeParseNodeCallsHooksOnNestedObjects(t *testing.T) {
+	node := NewNativeParseNode(map[string]any{"name": "parent", "child": map[string]any{"name": "child"}})
+	calls := make([]string, 0)
+	_ = node.SetOnBeforeAssignFieldValues(func(item Parsable) error {
+		calls = append(calls, "before")
+		return nil
+	})
+	_ = node.SetOnAfterAssignFieldValues(func(item Parsable) error {
+		calls = append(calls, "after")
+		return nil
+	})
+	_, err := node.GetObjectValue(createNativeTestModelFromDiscriminatorValue)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"before", "before", "after", "after"}, calls)
+}
@@ -0,0 +1,478 @@
+package serialization
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NativeSerializationWriter is a SerializationWriter that builds a tree of native Go values instead of serialized content.
+// Objects become map[string]any, collections become []any and primitives are stored as values rather than pointers.
+type NativeSerializationWriter struct {
+	root                       any
+	current                    map[string]any
+	onBeforeAssignFieldValues  ParsableAction
+	onAfterAssignFieldValues   ParsableAction
+	onStartObjectSerialization ParsableWriter
+}
+
+// NewNativeSerializationWriter creates a new instance of the NativeSerializationWriter.
+func NewNativeSerializationWriter() *NativeSerializationWriter {
+	return &NativeSerializationWriter{}
+}
+
+// ToNative converts the model into a tree of native Go values, map[string]any for objects, []any for collections and primitive values.
+func ToNative(model Parsable) (any, error) {
+	writer := NewNativeSerializationWriter()
+	defer writer.Close()
+	if err := writer.WriteObjectValue("", model); err != nil {
+		return nil, err
+	}
+	return writer.GetValue(), nil
+}
+
+// GetValue returns the tree of native values written so far.
+func (w *NativeSerializationWriter) GetValue() any {
+	return w.root
+}
+
+func (w *NativeSerializationWriter) setValue(key string, value any) error {
+	if w.current == nil {
+		if key == "" {
+			w.root = value
+			return nil
+		}
+		w.current = make(map[string]any)
+		w.root = w.current
+	}
+	if key == "" {
+		return errors.New("key cannot be empty when writing a value inside an object")
+	}
+	w.current[key] = value
+	return nil
+}
+
+func writeNativePointer[T any](w *NativeSerializationWriter, key string, value *T) error {
+	if value == nil {
+		return nil
+	}
+	return w.setValue(key, *value)
+}
+
+func writeNativeCollection[T any](w *NativeSerializationWriter, key string, collection []T) error {
+	if collection == nil {
+		return nil
+	}
+	result := make([]any, len(collection))
+	for i, item := range collection {
+		result[i] = item
+	}
+	return w.setValue(key, result)
+}
+
+// WriteStringValue writes a String value to the tree.
+func (w *NativeSerializationWriter) WriteStringValue(key string, value *string) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteBoolValue writes a Bool value to the tree.
+func (w *NativeSerializationWriter) WriteBoolValue(key string, value *bool) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteByteValue writes a Byte value to the tree.
+func (w *NativeSerializationWriter) WriteByteValue(key string, value *byte) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteInt8Value writes a int8 value to the tree.
+func (w *NativeSerializationWriter) WriteInt8Value(key string, value *int8) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteInt32Value writes a Int32 value to the tree.
+func (w *NativeSerializationWriter) WriteInt32Value(key string, value *int32) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteInt64Value writes a Int64 value to the tree.
+func (w *NativeSerializationWriter) WriteInt64Value(key string, value *int64) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteFloat32Value writes a Float32 value to the tree.
+func (w *NativeSerializationWriter) WriteFloat32Value(key string, value *float32) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteFloat64Value writes a Float64 value to the tree.
+func (w *NativeSerializationWriter) WriteFloat64Value(key string, value *float64) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteByteArrayValue writes a ByteArray value to the tree.
+func (w *NativeSerializationWriter) WriteByteArrayValue(key string, value []byte) error {
+	if value == nil {
+		return nil
+	}
+	return w.setValue(key, value)
+}
+
+// WriteTimeValue writes a Time value to the tree.
+func (w *NativeSerializationWriter) WriteTimeValue(key string, value *time.Time) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteTimeOnlyValue writes the time part of a Time value to the tree.
+func (w *NativeSerializationWriter) WriteTimeOnlyValue(key string, value *TimeOnly) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteDateOnlyValue writes the date part of a Time value to the tree.
+func (w *NativeSerializationWriter) WriteDateOnlyValue(key string, value *DateOnly) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteISODurationValue writes a ISODuration value to the tree.
+func (w *NativeSerializationWriter) WriteISODurationValue(key string, value *ISODuration) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteUUIDValue writes a UUID value to the tree.
+func (w *NativeSerializationWriter) WriteUUIDValue(key string, value *uuid.UUID) error {
+	return writeNativePointer(w, key, value)
+}
+
+// WriteObjectValue writes a Parsable value to the tree as a map.
+// An object written without a key inside another object is merged into it.
+func (w *NativeSerializationWriter) WriteObjectValue(key string, item Parsable, additionalValuesToMerge ...Parsable) error {
+	values := make([]Parsable, 0, len(additionalValuesToMerge)+1)
+	for _, value := range append([]Parsable{item}, additionalValuesToMerge...) {
+		if !isNilParsable(value) {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	if untyped, ok := values[0].(UntypedNodeable); ok && len(values) == 1 && isUntypedValue(untyped) {
+		return WriteUntypedValue(w, key, untyped)
+	}
+	if key == "" && w.current != nil {
+		for _, value := range values {
+			if err := w.serializeObject(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	result, err := w.serializeObjects(values...)
+	if err != nil {
+		return err
+	}
+	return w.setValue(key, result)
+}
+
+// serializeObjects serializes the values into a new map.
+func (w *NativeSerializationWriter) serializeObjects(values ...Parsable) (map[string]any, error) {
+	parent := w.current
+	w.current = make(map[string]any)
+	defer func() { w.current = parent }()
+	for _, value := range values {
+		if err := w.serializeObject(value); err != nil {
+			return nil, err
+		}
+	}
+	return w.current, nil
+}
+
+func (w *NativeSerializationWriter) serializeObject(item Parsable) error {
+	if w.onBeforeAssignFieldValues != nil {
+		if err := w.onBeforeAssignFieldValues(item); err != nil {
+			return err
+		}
+	}
+	if w.onStartObjectSerialization != nil {
+		if err := w.onStartObjectSerialization(item, w); err != nil {
+			return err
+		}
+	}
+	if err := item.Serialize(w); err != nil {
+		return err
+	}
+	if w.onAfterAssignFieldValues != nil {
+		return w.onAfterAssignFieldValues(item)
+	}
+	return nil
+}
+
+// isUntypedValue returns whether the node is one of the untyped nodes holding a value other than an object.
+func isUntypedValue(node UntypedNodeable) bool {
+	switch node.(type) {
+	case *UntypedNull, *UntypedString, *UntypedBoolean, *UntypedInteger, *UntypedLong, *UntypedFloat, *UntypedDouble, *UntypedArray, *UntypedNode:
+		return true
+	}
+	return false
+}
+
+// nativeUntypedValue returns the native value of an untyped node that is not an object.
+func nativeUntypedValue(node UntypedNodeable) (any, error) {
+	writer := NewNativeSerializationWriter()
+	if err := WriteUntypedValue(writer, "", node); err != nil {
+		return nil, err
+	}
+	return writer.GetValue(), nil
+}
+
+func isNilParsable(value Parsable) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// WriteCollectionOfObjectValues writes a collection of Parsable values to the tree as a slice of maps.
+func (w *NativeSerializationWriter) WriteCollectionOfObjectValues(key string, collection []Parsable) error {
+	if collection == nil {
+		return nil
+	}
+	result := make([]any, len(collection))
+	for i, item := range collection {
+		if isNilParsable(item) {
+			continue
+		}
+		if untyped, ok := item.(UntypedNodeable); ok && isUntypedValue(untyped) {
+			value, err := nativeUntypedValue(untyped)
+			if err != nil {
+				return err
+			}
+			result[i] = value
+			continue
+		}
+		value, err := w.serializeObjects(item)
+		if err != nil {
+			return err
+		}
+		result[i] = value
+	}
+	return w.setValue(key, result)
+}
+
+// WriteCollectionOfStringValues writes a collection of String values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfStringValues(key string, collection []string) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfBoolValues writes a collection of Bool values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfBoolValues(key string, collection []bool) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfByteValues writes a collection of Byte values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfByteValues(key string, collection []byte) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfInt8Values writes a collection of int8 values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfInt8Values(key string, collection []int8) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfInt32Values writes a collection of Int32 values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfInt32Values(key string, collection []int32) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfInt64Values writes a collection of Int64 values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfInt64Values(key string, collection []int64) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfFloat32Values writes a collection of Float32 values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfFloat32Values(key string, collection []float32) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfFloat64Values writes a collection of Float64 values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfFloat64Values(key string, collection []float64) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfTimeValues writes a collection of Time values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfTimeValues(key string, collection []time.Time) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfISODurationValues writes a collection of ISODuration values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfISODurationValues(key string, collection []ISODuration) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfDateOnlyValues writes a collection of DateOnly values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfDateOnlyValues(key string, collection []DateOnly) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfTimeOnlyValues writes a collection of TimeOnly values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfTimeOnlyValues(key string, collection []TimeOnly) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// WriteCollectionOfUUIDValues writes a collection of UUID values to the tree.
+func (w *NativeSerializationWriter) WriteCollectionOfUUIDValues(key string, collection []uuid.UUID) error {
+	return writeNativeCollection(w, key, collection)
+}
+
+// GetSerializedContent returns an error as the writer builds native values, use GetValue instead.
+func (w *NativeSerializationWriter) GetSerializedContent() ([]byte, error) {
+	return nil, errors.New("the native serialization writer does not produce serialized content, use GetValue instead")
+}
+
+// WriteNullValue writes a nil value for the specified key.
+func (w *NativeSerializationWriter) WriteNullValue(key string) error {
+	return w.setValue(key, nil)
+}
+
+// WriteAdditionalData writes additional data to the tree.
+func (w *NativeSerializationWriter) WriteAdditionalData(value map[string]interface{}) error {
+	for key, input := range value {
+		if err := w.WriteAnyValue(key, input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteAnyValue writes a value of unknown type to the tree.
+// Pointers are dereferenced, Parsable values are converted to maps and nested maps and slices are converted recursively.
+func (w *NativeSerializationWriter) WriteAnyValue(key string, value interface{}) error {
+	if value == nil {
+		return w.WriteNullValue(key)
+	}
+	switch v := value.(type) {
+	case Parsable:
+		if isNilParsable(v) {
+			return w.WriteNullValue(key)
+		}
+		return w.WriteObjectValue(key, v)
+	case []Parsable:
+		return w.WriteCollectionOfObjectValues(key, v)
+	}
+	result, err := w.toNativeValue(value)
+	if err != nil {
+		return fmt.Errorf("could not write the value of %q: %w", key, err)
+	}
+	return w.setValue(key, result)
+}
+
+// toNativeValue converts a value of unknown type into its native representation.
+func (w *NativeSerializationWriter) toNativeValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch v := value.(type) {
+	case Parsable:
+		if isNilParsable(v) {
+			return nil, nil
+		}
+		return w.serializeObjects(v)
+	case []byte:
+		return v, nil
+	case string, bool, byte, int8, int16, int32, int64, int, uint16, uint32, uint64, uint, float32, float64,
+		time.Time, TimeOnly, DateOnly, ISODuration, uuid.UUID:
+		return v, nil
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if reflected.IsNil() {
+			return nil, nil
+		}
+		return w.toNativeValue(reflected.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if reflected.Kind() == reflect.Slice && reflected.IsNil() {
+			return nil, nil
+		}
+		result := make([]any, reflected.Len())
+		for i := range result {
+			item, err := w.toNativeValue(reflected.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			result[i] = item
+		}
+		return result, nil
+	case reflect.Map:
+		if reflected.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", reflected.Type().Key())
+		}
+		if reflected.IsNil() {
+			return nil, nil
+		}
+		result := make(map[string]any, reflected.Len())
+		iterator := reflected.MapRange()
+		for iterator.Next() {
+			item, err := w.toNativeValue(iterator.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			result[iterator.Key().String()] = item
+		}
+		return result, nil
+	case reflect.String:
+		return reflected.String(), nil
+	case reflect.Bool:
+		return reflected.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflected.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflected.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return reflected.Float(), nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", value)
+}
+
+// GetOnBeforeSerialization returns a callback invoked before the serialization process starts.
+func (w *NativeSerializationWriter) GetOnBeforeSerialization() ParsableAction {
+	return w.onBeforeAssignFieldValues
+}
+
+// SetOnBeforeSerialization sets a callback invoked before the serialization process starts.
+func (w *NativeSerializationWriter) SetOnBeforeSerialization(action ParsableAction) error {
+	w.onBeforeAssignFieldValues = action
+	return nil
+}
+
+// GetOnAfterObjectSerialization returns a callback invoked after the serialization process completes.
+func (w *NativeSerializationWriter) GetOnAfterObjectSerialization() ParsableAction {
+	return w.onAfterAssignFieldValues
+}
+
+// SetOnAfterObjectSerialization sets a callback invoked after the serialization process completes.
+func (w *NativeSerializationWriter) SetOnAfterObjectSerialization(action ParsableAction) error {
+	w.onAfterAssignFieldValues = action
+	return nil
+}
+
+// GetOnStartObjectSerialization returns a callback invoked right after the serialization process starts.
+func (w *NativeSerializationWriter) GetOnStartObjectSerialization() ParsableWriter {
+	return w.onStartObjectSerialization
+}
+
+// SetOnStartObjectSerialization sets a callback invoked right after the serialization process starts.
+func (w *NativeSerializationWriter) SetOnStartObjectSerialization(writer ParsableWriter) error {
+	w.onStartObjectSerialization = writer
+	return nil
+}
+
+// Close clears the tree written so far.
+func (w *NativeSerializationWriter) Close() error {
+	w.root = nil
+	w.current = nil
+	return nil
+}
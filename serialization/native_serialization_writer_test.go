@@ -0,0 +1,103 @@
+package serialization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestNativeSerializationWriterHonoursInterface(t *testing.T) {
+	assert.Implements(t, (*SerializationWriter)(nil), NewNativeSerializationWriter())
+}
+
+func TestToNativeBuildsTree(t *testing.T) {
+	name := "parent"
+	childName := "child"
+	count := int32(2)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	model := &nativeTestModel{
+		name:      &name,
+		count:     &count,
+		tags:      []string{"a", "b"},
+		createdAt: &createdAt,
+		birthday:  NewDateOnly(createdAt),
+		child:     &nativeTestModel{name: &childName},
+		children:  []*nativeTestModel{{name: &childName}, nil},
+		additionalData: map[string]any{
+			"pointer": &name,
+			"nested":  map[string]any{"values": []int{1, 2}},
+			"empty":   nil,
+		},
+	}
+	result, err := ToNative(model)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{
+		"name":      "parent",
+		"count":     int32(2),
+		"tags":      []any{"a", "b"},
+		"createdAt": createdAt,
+		"birthday":  *NewDateOnly(createdAt),
+		"child":     map[string]any{"name": "child"},
+		"children":  []any{map[string]any{"name": "child"}, nil},
+		"pointer":   "parent",
+		"nested":    map[string]any{"values": []any{1, 2}},
+		"empty":     nil,
+	}, result)
+}
+
+func TestToNativeReturnsNilForNilModel(t *testing.T) {
+	result, err := ToNative(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestNativeSerializationWriterWritesPrimitivesAtRoot(t *testing.T) {
+	id := uuid.New()
+	writer := NewNativeSerializationWriter()
+	assert.Nil(t, writer.WriteUUIDValue("", &id))
+	assert.Equal(t, id, writer.GetValue())
+
+	writer = NewNativeSerializationWriter()
+	assert.Nil(t, writer.WriteCollectionOfInt64Values("", []int64{1, 2}))
+	assert.Equal(t, []any{int64(1), int64(2)}, writer.GetValue())
+
+	_, err := writer.GetSerializedContent()
+	assert.NotNil(t, err)
+}
+
+func TestNativeSerializationWriterMergesAdditionalValues(t *testing.T) {
+	first := "first"
+	second := "second"
+	writer := NewNativeSerializationWriter()
+	err := writer.WriteObjectValue("", &nativeTestModel{name: &first}, &nativeTestModel{additionalData: map[string]any{"other": second}})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"name": "first", "other": "second"}, writer.GetValue())
+}
+
+func TestNativeSerializationWriterCallsHooks(t *testing.T) {
+	name := "parent"
+	writer := NewNativeSerializationWriter()
+	calls := make([]string, 0)
+	_ = writer.SetOnBeforeSerialization(func(Parsable) error {
+		calls = append(calls, "before")
+		return nil
+	})
+	_ = writer.SetOnStartObjectSerialization(func(item Parsable, w SerializationWriter) error {
+		calls = append(calls, "start")
+		return w.WriteNullValue("cleared")
+	})
+	_ = writer.SetOnAfterObjectSerialization(func(Parsable) error {
+		calls = append(calls, "after")
+		return nil
+	})
+	err := writer.WriteObjectValue("", &nativeTestModel{name: &name, child: &nativeTestModel{}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"before", "start", "before", "start", "after", "after"}, calls)
+	assert.Equal(t, map[string]any{
+		"name":    "parent",
+		"cleared": nil,
+		"child":   map[string]any{"cleared": nil},
+	}, writer.GetValue())
+}
@@ -0,0 +1,122 @@
+package serialization
+
+import (
+	"time"
+)
+
+type nativeTestModel struct {
+	name           *string
+	count          *int32
+	tags           []string
+	createdAt      *time.Time
+	birthday       *DateOnly
+	child          *nativeTestModel
+	children       []*nativeTestModel
+	additionalData map[string]any
+}
+
+func createNativeTestModelFromDiscriminatorValue(parseNode ParseNode) (Parsable, error) {
+	return &nativeTestModel{}, nil
+}
+
+func (m *nativeTestModel) GetAdditionalData() map[string]any {
+	return m.additionalData
+}
+
+func (m *nativeTestModel) SetAdditionalData(value map[string]any) {
+	m.additionalData = value
+}
+
+func (m *nativeTestModel) Serialize(writer SerializationWriter) error {
+	if err := writer.WriteStringValue("name", m.name); err != nil {
+		return err
+	}
+	if err := writer.WriteInt32Value("count", m.count); err != nil {
+		return err
+	}
+	if err := writer.WriteCollectionOfStringValues("tags", m.tags); err != nil {
+		return err
+	}
+	if err := writer.WriteTimeValue("createdAt", m.createdAt); err != nil {
+		return err
+	}
+	if err := writer.WriteDateOnlyValue("birthday", m.birthday); err != nil {
+		return err
+	}
+	if m.child != nil {
+		if err := writer.WriteObjectValue("child", m.child); err != nil {
+			return err
+		}
+	}
+	if m.children != nil {
+		children := make([]Parsable, len(m.children))
+		for i, child := range m.children {
+			children[i] = child
+		}
+		if err := writer.WriteCollectionOfObjectValues("children", children); err != nil {
+			return err
+		}
+	}
+	return writer.WriteAdditionalData(m.additionalData)
+}
+
+func (m *nativeTestModel) GetFieldDeserializers() map[string]func(ParseNode) error {
+	return map[string]func(ParseNode) error{
+		"name": func(n ParseNode) error {
+			val, err := n.GetStringValue()
+			m.name = val
+			return err
+		},
+		"count": func(n ParseNode) error {
+			val, err := n.GetInt32Value()
+			m.count = val
+			return err
+		},
+		"tags": func(n ParseNode) error {
+			val, err := n.GetCollectionOfPrimitiveValues("string")
+			if err != nil {
+				return err
+			}
+			m.tags = nil
+			for _, v := range val {
+				m.tags = append(m.tags, *(v.(*string)))
+			}
+			return nil
+		},
+		"createdAt": func(n ParseNode) error {
+			val, err := n.GetTimeValue()
+			m.createdAt = val
+			return err
+		},
+		"birthday": func(n ParseNode) error {
+			val, err := n.GetDateOnlyValue()
+			m.birthday = val
+			return err
+		},
+		"child": func(n ParseNode) error {
+			val, err := n.GetObjectValue(createNativeTestModelFromDiscriminatorValue)
+			if err != nil {
+				return err
+			}
+			if val != nil {
+				m.child = val.(*nativeTestModel)
+			}
+			return nil
+		},
+		"children": func(n ParseNode) error {
+			val, err := n.GetCollectionOfObjectValues(createNativeTestModelFromDiscriminatorValue)
+			if err != nil {
+				return err
+			}
+			m.children = nil
+			for _, v := range val {
+				if v == nil {
+					m.children = append(m.children, nil)
+					continue
+				}
+				m.children = append(m.children, v.(*nativeTestModel))
+			}
+			return nil
+		},
+	}
+}
@@ -7,3 +7,11 @@ type ParseNodeFactory interface {
 	// GetRootParseNode return a new ParseNode instance that is the root of the content
 	GetRootParseNode(contentType string, content []byte) (ParseNode, error)
 }
+
+// ContentTypeParametersParseNodeFactory is a ParseNodeFactory reading the parameters of the content type, e.g. the boundary of multipart content.
+// The ParseNodeFactoryRegistry passes these factories the content type with its parameters instead of the media type alone.
+type ContentTypeParametersParseNodeFactory interface {
+	ParseNodeFactory
+	// ReadsContentTypeParameters returns whether the parameters of the content type must be passed to the factory.
+	ReadsContentTypeParameters() bool
+}
@@ -2,6 +2,7 @@ package serialization
 
 import (
 	"errors"
+	"io"
 	re "regexp"
 	"strings"
 	"sync"
@@ -32,6 +33,17 @@ func (m *ParseNodeFactoryRegistry) GetValidContentType() (string, error) {
 	return "", errors.New("the registry supports multiple content types. Get the registered factory instead")
 }
 
+// UnregisteredContentTypeError is returned by the ParseNodeFactoryRegistry when no factory is registered for the content type.
+type UnregisteredContentTypeError struct {
+	// ContentType is the media type without vendor specific prefix.
+	ContentType string
+}
+
+// Error returns the error message.
+func (e *UnregisteredContentTypeError) Error() string {
+	return "content type " + e.ContentType + " does not have a factory registered to be parsed"
+}
+
 var contentTypeVendorCleanupPattern = re.MustCompile(`[^/]+\+`)
 
 // GetRootParseNode returns a new ParseNode instance that is the root of the content
@@ -42,17 +54,45 @@ func (m *ParseNodeFactoryRegistry) GetRootParseNode(contentType string, content
 	if content == nil {
 		return nil, errors.New("content is required")
 	}
-	vendorSpecificContentType := strings.Split(contentType, ";")[0]
-	factory, ok := m.ContentTypeAssociatedFactories[vendorSpecificContentType]
-	if ok {
-		return factory.GetRootParseNode(vendorSpecificContentType, content)
+	factory, factoryContentType, err := m.getFactory(contentType)
+	if err != nil {
+		return nil, err
+	}
+	return factory.GetRootParseNode(factoryContentType, content)
+}
+
+// GetRootParseNodeFromReader returns a new ParseNode instance that is the root of the content read from the reader.
+// The content is read fully in memory when the registered factory doesn't support readers.
+func (m *ParseNodeFactoryRegistry) GetRootParseNodeFromReader(contentType string, content io.Reader) (ParseNode, error) {
+	if contentType == "" {
+		return nil, errors.New("contentType is required")
+	}
+	if content == nil {
+		return nil, errors.New("content is required")
+	}
+	factory, factoryContentType, err := m.getFactory(contentType)
+	if err != nil {
+		return nil, err
+	}
+	return NewRootParseNodeFromReader(factory, factoryContentType, content)
+}
+
+// getFactory returns the factory registered for the content type and the content type to pass it,
+// the parameters are kept for the factories reading them.
+func (m *ParseNodeFactoryRegistry) getFactory(contentType string) (ParseNodeFactory, string, error) {
+	vendorSpecificContentType, parameters, _ := strings.Cut(contentType, ";")
+	factory, factoryContentType := m.ContentTypeAssociatedFactories[vendorSpecificContentType], vendorSpecificContentType
+	if factory == nil {
+		factoryContentType = contentTypeVendorCleanupPattern.ReplaceAllString(vendorSpecificContentType, "")
+		factory = m.ContentTypeAssociatedFactories[factoryContentType]
+	}
+	if factory == nil {
+		return nil, "", &UnregisteredContentTypeError{ContentType: factoryContentType}
 	}
-	cleanedContentType := contentTypeVendorCleanupPattern.ReplaceAllString(vendorSpecificContentType, "")
-	factory, ok = m.ContentTypeAssociatedFactories[cleanedContentType]
-	if ok {
-		return factory.GetRootParseNode(cleanedContentType, content)
+	if parametersFactory, ok := factory.(ContentTypeParametersParseNodeFactory); ok && parameters != "" && parametersFactory.ReadsContentTypeParameters() {
+		factoryContentType += ";" + parameters
 	}
-	return nil, errors.New("content type " + cleanedContentType + " does not have a factory registered to be parsed")
+	return factory, factoryContentType, nil
 }
 
 func (m *ParseNodeFactoryRegistry) Lock() {
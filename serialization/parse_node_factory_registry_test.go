@@ -1,6 +1,7 @@
 package serialization
 
 import (
+	"errors"
 	"testing"
 
 	assert "github.com/stretchr/testify/assert"
@@ -9,3 +10,45 @@ import (
 func TestParseNodeFactoryRegistryHonoursInterface(t *testing.T) {
 	assert.Implements(t, (*ParseNodeFactory)(nil), DefaultParseNodeFactoryInstance)
 }
+
+type contentTypeRecordingParseNodeFactory struct {
+	readsParameters bool
+	contentType     string
+}
+
+func (f *contentTypeRecordingParseNodeFactory) GetValidContentType() (string, error) {
+	return "multipart/form-data", nil
+}
+
+func (f *contentTypeRecordingParseNodeFactory) GetRootParseNode(contentType string, content []byte) (ParseNode, error) {
+	f.contentType = contentType
+	return NewNativeParseNode(nil), nil
+}
+
+func (f *contentTypeRecordingParseNodeFactory) ReadsContentTypeParameters() bool {
+	return f.readsParameters
+}
+
+func TestParseNodeFactoryRegistryPassesTheParametersToTheFactoriesReadingThem(t *testing.T) {
+	registry := NewParseNodeFactoryRegistry()
+	factory := &contentTypeRecordingParseNodeFactory{readsParameters: true}
+	registry.ContentTypeAssociatedFactories["multipart/form-data"] = factory
+
+	_, err := registry.GetRootParseNode("multipart/form-data; boundary=abc", []byte{})
+	assert.Nil(t, err)
+	assert.Equal(t, "multipart/form-data; boundary=abc", factory.contentType)
+
+	factory.readsParameters = false
+	_, err = registry.GetRootParseNode("multipart/form-data; boundary=abc", []byte{})
+	assert.Nil(t, err)
+	assert.Equal(t, "multipart/form-data", factory.contentType)
+}
+
+func TestParseNodeFactoryRegistryReturnsAnErrorForUnregisteredContentTypes(t *testing.T) {
+	registry := NewParseNodeFactoryRegistry()
+	_, err := registry.GetRootParseNode("application/vnd.custom+json; charset=utf-8", []byte{})
+	var unregistered *UnregisteredContentTypeError
+	assert.True(t, errors.As(err, &unregistered))
+	assert.Equal(t, "application/json", unregistered.ContentType)
+	assert.Equal(t, "content type application/json does not have a factory registered to be parsed", err.Error())
+}
@@ -1,5 +1,7 @@
 package serialization
 
+import "io"
+
 type ParseNodeProxyFactory struct {
 	factory        ParseNodeFactory
 	onBeforeAction ParsableAction
@@ -28,9 +30,21 @@ func (p *ParseNodeProxyFactory) GetRootParseNode(contentType string, content []b
 	if err != nil {
 		return nil, err
 	}
+	return p.proxyNode(node)
+}
 
+// GetRootParseNodeFromReader returns a new ParseNode from the proxied factory reading its content from the reader.
+func (p *ParseNodeProxyFactory) GetRootParseNodeFromReader(contentType string, content io.Reader) (ParseNode, error) {
+	node, err := NewRootParseNodeFromReader(p.factory, contentType, content)
+	if err != nil {
+		return nil, err
+	}
+	return p.proxyNode(node)
+}
+
+func (p *ParseNodeProxyFactory) proxyNode(node ParseNode) (ParseNode, error) {
 	originalBefore := node.GetOnBeforeAssignFieldValues()
-	err = node.SetOnBeforeAssignFieldValues(func(parsable Parsable) error {
+	err := node.SetOnBeforeAssignFieldValues(func(parsable Parsable) error {
 		if parsable != nil {
 			err := p.onBeforeAction(parsable)
 			if err != nil {
@@ -51,8 +65,8 @@ func (p *ParseNodeProxyFactory) GetRootParseNode(contentType string, content []b
 
 	originalAfter := node.GetOnAfterAssignFieldValues()
 	err = node.SetOnAfterAssignFieldValues(func(parsable Parsable) error {
-		if p != nil {
-			err := p.onBeforeAction(parsable)
+		if parsable != nil {
+			err := p.onAfterAction(parsable)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,82 @@
+package serialization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hookParseNode is a ParseNode calling its hooks around the assignment of the fields, the other methods are not implemented.
+type hookParseNode struct {
+	ParseNode
+	calls                     *[]string
+	onBeforeAssignFieldValues ParsableAction
+	onAfterAssignFieldValues  ParsableAction
+}
+
+func (n *hookParseNode) GetObjectValue(ctor ParsableFactory) (Parsable, error) {
+	result, err := ctor(n)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.onBeforeAssignFieldValues(result); err != nil {
+		return nil, err
+	}
+	*n.calls = append(*n.calls, "assign")
+	if err := n.onAfterAssignFieldValues(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (n *hookParseNode) GetOnBeforeAssignFieldValues() ParsableAction {
+	return n.onBeforeAssignFieldValues
+}
+
+func (n *hookParseNode) SetOnBeforeAssignFieldValues(action ParsableAction) error {
+	n.onBeforeAssignFieldValues = action
+	return nil
+}
+
+func (n *hookParseNode) GetOnAfterAssignFieldValues() ParsableAction {
+	return n.onAfterAssignFieldValues
+}
+
+func (n *hookParseNode) SetOnAfterAssignFieldValues(action ParsableAction) error {
+	n.onAfterAssignFieldValues = action
+	return nil
+}
+
+type hookParseNodeFactory struct {
+	calls *[]string
+}
+
+func (f *hookParseNodeFactory) GetValidContentType() (string, error) {
+	return "application/json", nil
+}
+
+func (f *hookParseNodeFactory) GetRootParseNode(contentType string, content []byte) (ParseNode, error) {
+	return &hookParseNode{calls: f.calls}, nil
+}
+
+func TestParseNodeProxyFactoryCallsTheAfterActionOnceTheFieldsAreAssigned(t *testing.T) {
+	calls := make([]string, 0)
+	record := func(call string) ParsableAction {
+		return func(parsable Parsable) error {
+			calls = append(calls, call)
+			return nil
+		}
+	}
+	proxied := &hookParseNodeFactory{calls: &calls}
+	factory := NewParseNodeProxyFactory(proxied, record("before"), record("after"))
+
+	node, err := proxied.GetRootParseNode("application/json", []byte("{}"))
+	assert.Nil(t, err)
+	assert.Nil(t, node.SetOnAfterAssignFieldValues(record("original after")))
+	node, err = factory.proxyNode(node)
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(CreateUntypedNodeFromDiscriminatorValue)
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, []string{"before", "assign", "after", "original after"}, calls)
+}
@@ -39,8 +39,9 @@ type SerializationWriter interface {
 	// WriteUUIDValue writes a UUID value to underlying the byte array.
 	WriteUUIDValue(key string, value *uuid.UUID) error
 	// WriteObjectValue writes a Parsable value to underlying the byte array.
+	// Untyped nodes other than UntypedObject have no properties to serialize, implementations must write them with WriteUntypedValue.
 	WriteObjectValue(key string, item Parsable, additionalValuesToMerge ...Parsable) error
-	// WriteCollectionOfObjectValues writes a collection of Parsable values to underlying the byte array.
+	// WriteCollectionOfObjectValues writes a collection of Parsable values to underlying the byte array, untyped nodes are written as with WriteObjectValue.
 	WriteCollectionOfObjectValues(key string, collection []Parsable) error
 	// WriteCollectionOfStringValues writes a collection of String values to underlying the byte array.
 	WriteCollectionOfStringValues(key string, collection []string) error
@@ -2,6 +2,7 @@ package serialization
 
 import (
 	"errors"
+	"io"
 	"strings"
 	"sync"
 )
@@ -33,20 +34,38 @@ func (m *SerializationWriterFactoryRegistry) GetValidContentType() (string, erro
 
 // GetSerializationWriter returns the relevant SerializationWriter instance for the given content type
 func (m *SerializationWriterFactoryRegistry) GetSerializationWriter(contentType string) (SerializationWriter, error) {
+	factory, factoryContentType, err := m.getFactory(contentType)
+	if err != nil {
+		return nil, err
+	}
+	return factory.GetSerializationWriter(factoryContentType)
+}
+
+// GetStreamSerializationWriter returns the relevant SerializationWriter instance for the given content type writing its output to w.
+// The content is buffered until the writer is closed when the registered factory doesn't support streaming.
+func (m *SerializationWriterFactoryRegistry) GetStreamSerializationWriter(contentType string, w io.Writer) (SerializationWriter, error) {
+	factory, factoryContentType, err := m.getFactory(contentType)
+	if err != nil {
+		return nil, err
+	}
+	return NewStreamSerializationWriter(factory, factoryContentType, w)
+}
+
+func (m *SerializationWriterFactoryRegistry) getFactory(contentType string) (SerializationWriterFactory, string, error) {
 	if contentType == "" {
-		return nil, errors.New("the content type is empty")
+		return nil, "", errors.New("the content type is empty")
 	}
 	vendorSpecificContentType := strings.Split(contentType, ";")[0]
 	factory, ok := m.ContentTypeAssociatedFactories[vendorSpecificContentType]
 	if ok {
-		return factory.GetSerializationWriter(contentType)
+		return factory, contentType, nil
 	}
 	cleanedContentType := contentTypeVendorCleanupPattern.ReplaceAllString(vendorSpecificContentType, "")
 	factory, ok = m.ContentTypeAssociatedFactories[cleanedContentType]
 	if ok {
-		return factory.GetSerializationWriter(cleanedContentType)
+		return factory, cleanedContentType, nil
 	}
-	return nil, errors.New("Content type " + cleanedContentType + " does not have a factory registered to be parsed")
+	return nil, "", errors.New("Content type " + cleanedContentType + " does not have a factory registered to be parsed")
 }
 
 func (m *SerializationWriterFactoryRegistry) Lock() {
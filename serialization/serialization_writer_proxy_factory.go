@@ -1,5 +1,7 @@
 package serialization
 
+import "io"
+
 // ParsableAction Encapsulates a method with a single Parsable parameter
 type ParsableAction func(Parsable) error
 
@@ -38,9 +40,21 @@ func (s *SerializationWriterProxyFactory) GetSerializationWriter(contentType str
 	if err != nil {
 		return nil, err
 	}
+	return s.proxyWriter(writer)
+}
+
+// GetStreamSerializationWriter returns a SerializationWriter from the proxied factory writing its output to w.
+func (s *SerializationWriterProxyFactory) GetStreamSerializationWriter(contentType string, w io.Writer) (SerializationWriter, error) {
+	writer, err := NewStreamSerializationWriter(s.factory, contentType, w)
+	if err != nil {
+		return nil, err
+	}
+	return s.proxyWriter(writer)
+}
 
+func (s *SerializationWriterProxyFactory) proxyWriter(writer SerializationWriter) (SerializationWriter, error) {
 	originalBefore := writer.GetOnBeforeSerialization()
-	err = writer.SetOnBeforeSerialization(func(parsable Parsable) error {
+	err := writer.SetOnBeforeSerialization(func(parsable Parsable) error {
 		if s != nil {
 			err := s.onBeforeAction(parsable)
 			if err != nil {
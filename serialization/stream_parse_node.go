@@ -0,0 +1,66 @@
+package serialization
+
+import (
+	"errors"
+	"io"
+	"iter"
+)
+
+// StreamParseNodeFactory defines the contract for a factory that creates ParseNode instances reading their content from an io.Reader.
+type StreamParseNodeFactory interface {
+	ParseNodeFactory
+	// GetRootParseNodeFromReader returns a new ParseNode instance that is the root of the content read from the reader.
+	// The content is read as the parse node is traversed, the reader must not be used by the caller until traversal completes.
+	GetRootParseNodeFromReader(contentType string, content io.Reader) (ParseNode, error)
+}
+
+// CollectionIteratorParseNode defines a ParseNode able to yield the items of a collection one at a time instead of materialising the whole collection.
+type CollectionIteratorParseNode interface {
+	ParseNode
+	// IterateCollectionOfObjectValues returns a sequence of the Parsable values from the node.
+	// Iteration stops after the first error is yielded.
+	IterateCollectionOfObjectValues(ctor ParsableFactory) iter.Seq2[Parsable, error]
+}
+
+// NewRootParseNodeFromReader returns a new ParseNode instance that is the root of the content read from the reader.
+// When the factory doesn't implement StreamParseNodeFactory, the content is read fully in memory before the parse node is created.
+func NewRootParseNodeFromReader(factory ParseNodeFactory, contentType string, content io.Reader) (ParseNode, error) {
+	if factory == nil {
+		return nil, errors.New("the factory is empty")
+	}
+	if content == nil {
+		return nil, errors.New("content is required")
+	}
+	if streamFactory, ok := factory.(StreamParseNodeFactory); ok {
+		return streamFactory.GetRootParseNodeFromReader(contentType, content)
+	}
+	buffer, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	return factory.GetRootParseNode(contentType, buffer)
+}
+
+// IterateCollectionOfObjectValues returns a sequence of the Parsable values from the node.
+// When the node doesn't implement CollectionIteratorParseNode, the collection is materialised before the first item is yielded.
+func IterateCollectionOfObjectValues(node ParseNode, ctor ParsableFactory) iter.Seq2[Parsable, error] {
+	if iteratorNode, ok := node.(CollectionIteratorParseNode); ok {
+		return iteratorNode.IterateCollectionOfObjectValues(ctor)
+	}
+	return func(yield func(Parsable, error) bool) {
+		if node == nil {
+			yield(nil, errors.New("the parse node is empty"))
+			return
+		}
+		collection, err := node.GetCollectionOfObjectValues(ctor)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, item := range collection {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
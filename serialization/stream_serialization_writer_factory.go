@@ -0,0 +1,73 @@
+package serialization
+
+import (
+	"errors"
+	"io"
+)
+
+// StreamSerializationWriterFactory defines the contract for a factory that creates SerializationWriter instances writing incrementally to an io.Writer.
+type StreamSerializationWriterFactory interface {
+	SerializationWriterFactory
+	// GetStreamSerializationWriter returns a SerializationWriter for the given content type writing its output to w as values are written.
+	// Closing the writer flushes any pending output to w, GetSerializedContent is not meant to be called on such writers.
+	GetStreamSerializationWriter(contentType string, w io.Writer) (SerializationWriter, error)
+}
+
+// NewStreamSerializationWriter returns a SerializationWriter for the given content type writing its output to w.
+// When the factory doesn't implement StreamSerializationWriterFactory, the content is buffered by the writer and copied to w when it is closed.
+// The writer must be closed for the output to be complete.
+func NewStreamSerializationWriter(factory SerializationWriterFactory, contentType string, w io.Writer) (SerializationWriter, error) {
+	if factory == nil {
+		return nil, errors.New("the factory is empty")
+	}
+	if w == nil {
+		return nil, errors.New("the io.Writer is empty")
+	}
+	if streamFactory, ok := factory.(StreamSerializationWriterFactory); ok {
+		return streamFactory.GetStreamSerializationWriter(contentType, w)
+	}
+	writer, err := factory.GetSerializationWriter(contentType)
+	if err != nil {
+		return nil, err
+	} else if writer == nil {
+		return nil, errors.New("the writer is empty")
+	}
+	return &bufferedStreamSerializationWriter{
+		SerializationWriter: writer,
+		output:              w,
+	}, nil
+}
+
+// bufferedStreamSerializationWriter copies the content of a SerializationWriter to an io.Writer when it is closed.
+type bufferedStreamSerializationWriter struct {
+	SerializationWriter
+	output io.Writer
+	closed bool
+}
+
+// Close copies the serialized content to the io.Writer and closes the underlying writer.
+func (b *bufferedStreamSerializationWriter) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	err := b.flush()
+	closeErr := b.SerializationWriter.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+func (b *bufferedStreamSerializationWriter) flush() error {
+	if writerTo, ok := b.SerializationWriter.(io.WriterTo); ok {
+		_, err := writerTo.WriteTo(b.output)
+		return err
+	}
+	content, err := b.SerializationWriter.GetSerializedContent()
+	if err != nil {
+		return err
+	}
+	_, err = b.output.Write(content)
+	return err
+}
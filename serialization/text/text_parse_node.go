@@ -0,0 +1,180 @@
+package text
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/microsoft/kiota-abstractions-go/serialization/internal/parsing"
+)
+
+// TextParseNode is a ParseNode implementation for plain text content.
+// Only primitive values can be read, objects and collections return an error.
+type TextParseNode struct {
+	value                     string
+	onBeforeAssignFieldValues absser.ParsableAction
+	onAfterAssignFieldValues  absser.ParsableAction
+}
+
+// NewTextParseNode creates a new TextParseNode from the plain text content.
+func NewTextParseNode(content []byte) (*TextParseNode, error) {
+	if content == nil {
+		return nil, errors.New("content cannot be nil")
+	}
+	return &TextParseNode{value: strings.Trim(string(content), "\"")}, nil
+}
+
+func parseValue[T any](n *TextParseNode, parse func(string) (T, error)) (*T, error) {
+	if n.value == "" || n.value == "null" {
+		return nil, nil
+	}
+	result, err := parse(strings.TrimSpace(n.value))
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetChildNode returns an error as text content does not support structured data.
+func (n *TextParseNode) GetChildNode(index string) (absser.ParseNode, error) {
+	return nil, ErrNoStructuredData
+}
+
+// GetObjectValue returns an error as text content does not support objects.
+func (n *TextParseNode) GetObjectValue(ctor absser.ParsableFactory) (absser.Parsable, error) {
+	return nil, ErrNoStructuredData
+}
+
+// GetCollectionOfObjectValues returns an error as text content does not support collections.
+func (n *TextParseNode) GetCollectionOfObjectValues(ctor absser.ParsableFactory) ([]absser.Parsable, error) {
+	return nil, ErrNoStructuredData
+}
+
+// GetCollectionOfPrimitiveValues returns an error as text content does not support collections.
+func (n *TextParseNode) GetCollectionOfPrimitiveValues(targetType string) ([]interface{}, error) {
+	return nil, ErrNoStructuredData
+}
+
+// GetCollectionOfEnumValues returns an error as text content does not support collections.
+func (n *TextParseNode) GetCollectionOfEnumValues(parser absser.EnumFactory) ([]interface{}, error) {
+	return nil, ErrNoStructuredData
+}
+
+// GetStringValue returns a String value from the nodes.
+func (n *TextParseNode) GetStringValue() (*string, error) {
+	if n.value == "null" {
+		return nil, nil
+	}
+	value := n.value
+	return &value, nil
+}
+
+// GetBoolValue returns a Bool value from the nodes.
+func (n *TextParseNode) GetBoolValue() (*bool, error) {
+	return parseValue(n, strconv.ParseBool)
+}
+
+// GetInt8Value returns a int8 value from the nodes.
+func (n *TextParseNode) GetInt8Value() (*int8, error) {
+	return parseValue(n, parsing.Int[int8](8))
+}
+
+// GetByteValue returns a Byte value from the nodes.
+func (n *TextParseNode) GetByteValue() (*byte, error) {
+	return parseValue(n, parsing.Byte)
+}
+
+// GetFloat32Value returns a Float32 value from the nodes.
+func (n *TextParseNode) GetFloat32Value() (*float32, error) {
+	return parseValue(n, parsing.Float[float32](32))
+}
+
+// GetFloat64Value returns a Float64 value from the nodes.
+func (n *TextParseNode) GetFloat64Value() (*float64, error) {
+	return parseValue(n, parsing.Float[float64](64))
+}
+
+// GetInt32Value returns a int32 value from the nodes.
+func (n *TextParseNode) GetInt32Value() (*int32, error) {
+	return parseValue(n, parsing.Int[int32](32))
+}
+
+// GetInt64Value returns a int64 value from the nodes.
+func (n *TextParseNode) GetInt64Value() (*int64, error) {
+	return parseValue(n, parsing.Int[int64](64))
+}
+
+// GetTimeValue returns a Time value from the nodes.
+func (n *TextParseNode) GetTimeValue() (*time.Time, error) {
+	return parseValue(n, parsing.Time)
+}
+
+// GetISODurationValue returns a ISODuration value from the nodes.
+func (n *TextParseNode) GetISODurationValue() (*absser.ISODuration, error) {
+	return parseValue(n, parsing.Dereference(absser.ParseISODuration))
+}
+
+// GetTimeOnlyValue returns a TimeOnly value from the nodes.
+func (n *TextParseNode) GetTimeOnlyValue() (*absser.TimeOnly, error) {
+	return parseValue(n, parsing.Dereference(absser.ParseTimeOnly))
+}
+
+// GetDateOnlyValue returns a DateOnly value from the nodes.
+func (n *TextParseNode) GetDateOnlyValue() (*absser.DateOnly, error) {
+	return parseValue(n, parsing.Dereference(absser.ParseDateOnly))
+}
+
+// GetUUIDValue returns a UUID value from the nodes.
+func (n *TextParseNode) GetUUIDValue() (*uuid.UUID, error) {
+	return parseValue(n, uuid.Parse)
+}
+
+// GetEnumValue returns a Enum value from the nodes.
+func (n *TextParseNode) GetEnumValue(parser absser.EnumFactory) (interface{}, error) {
+	if parser == nil {
+		return nil, errors.New("parser is nil")
+	}
+	if n.value == "" || n.value == "null" {
+		return nil, nil
+	}
+	return parser(n.value)
+}
+
+// GetByteArrayValue returns a ByteArray value from the nodes.
+func (n *TextParseNode) GetByteArrayValue() ([]byte, error) {
+	if n.value == "" || n.value == "null" {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(n.value)
+}
+
+// GetRawValue returns the values of the node as an interface of any type.
+func (n *TextParseNode) GetRawValue() (interface{}, error) {
+	return n.GetStringValue()
+}
+
+// GetOnBeforeAssignFieldValues returns a callback invoked before the node is deserialized.
+func (n *TextParseNode) GetOnBeforeAssignFieldValues() absser.ParsableAction {
+	return n.onBeforeAssignFieldValues
+}
+
+// SetOnBeforeAssignFieldValues sets a callback invoked before the node is deserialized.
+func (n *TextParseNode) SetOnBeforeAssignFieldValues(action absser.ParsableAction) error {
+	n.onBeforeAssignFieldValues = action
+	return nil
+}
+
+// GetOnAfterAssignFieldValues returns a callback invoked after the node is deserialized.
+func (n *TextParseNode) GetOnAfterAssignFieldValues() absser.ParsableAction {
+	return n.onAfterAssignFieldValues
+}
+
+// SetOnAfterAssignFieldValues sets a callback invoked after the node is deserialized.
+func (n *TextParseNode) SetOnAfterAssignFieldValues(action absser.ParsableAction) error {
+	n.onAfterAssignFieldValues = action
+	return nil
+}
@@ -0,0 +1,31 @@
+package text
+
+import (
+	"errors"
+
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+)
+
+// TextParseNodeFactory implements ParseNodeFactory for plain text content.
+type TextParseNodeFactory struct {
+}
+
+// NewTextParseNodeFactory creates a new instance of the TextParseNodeFactory.
+func NewTextParseNodeFactory() *TextParseNodeFactory {
+	return &TextParseNodeFactory{}
+}
+
+// GetValidContentType returns the content type this factory's parse nodes can deserialize.
+func (f *TextParseNodeFactory) GetValidContentType() (string, error) {
+	return TextContentType, nil
+}
+
+// GetRootParseNode returns a new ParseNode instance that is the root of the content
+func (f *TextParseNodeFactory) GetRootParseNode(contentType string, content []byte) (absser.ParseNode, error) {
+	if contentType == "" {
+		return nil, errors.New("the content type is empty")
+	} else if mediaType(contentType) != TextContentType {
+		return nil, errors.New("the provided content type is not supported")
+	}
+	return NewTextParseNode(content)
+}
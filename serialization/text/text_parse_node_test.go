@@ -0,0 +1,128 @@
+package text
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/kiota-abstractions-go/internal"
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestParseNode(t *testing.T, content string) *TextParseNode {
+	node, err := NewTextParseNode([]byte(content))
+	assert.Nil(t, err)
+	return node
+}
+
+func TestTextParseNodeHonoursInterface(t *testing.T) {
+	assert.Implements(t, (*absser.ParseNode)(nil), newTestParseNode(t, ""))
+}
+
+func TestTextParseNodeReadsPrimitives(t *testing.T) {
+	stringValue, err := newTestParseNode(t, "\"text\"").GetStringValue()
+	assert.Nil(t, err)
+	assert.Equal(t, "text", *stringValue)
+
+	boolValue, err := newTestParseNode(t, "true").GetBoolValue()
+	assert.Nil(t, err)
+	assert.True(t, *boolValue)
+
+	int8Value, err := newTestParseNode(t, "-8").GetInt8Value()
+	assert.Nil(t, err)
+	assert.Equal(t, int8(-8), *int8Value)
+
+	byteValue, err := newTestParseNode(t, "8").GetByteValue()
+	assert.Nil(t, err)
+	assert.Equal(t, byte(8), *byteValue)
+
+	int32Value, err := newTestParseNode(t, "32").GetInt32Value()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(32), *int32Value)
+
+	int64Value, err := newTestParseNode(t, "64").GetInt64Value()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(64), *int64Value)
+
+	float32Value, err := newTestParseNode(t, "1.5").GetFloat32Value()
+	assert.Nil(t, err)
+	assert.Equal(t, float32(1.5), *float32Value)
+
+	float64Value, err := newTestParseNode(t, "2.25").GetFloat64Value()
+	assert.Nil(t, err)
+	assert.Equal(t, 2.25, *float64Value)
+
+	timeValue, err := newTestParseNode(t, "2024-01-02T03:04:05Z").GetTimeValue()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), timeValue.UTC())
+
+	dateOnlyValue, err := newTestParseNode(t, "2024-01-02").GetDateOnlyValue()
+	assert.Nil(t, err)
+	assert.Equal(t, "2024-01-02", dateOnlyValue.String())
+
+	timeOnlyValue, err := newTestParseNode(t, "03:04:05").GetTimeOnlyValue()
+	assert.Nil(t, err)
+	assert.Equal(t, "03:04:05", timeOnlyValue.String())
+
+	durationValue, err := newTestParseNode(t, "PT1H").GetISODurationValue()
+	assert.Nil(t, err)
+	assert.Equal(t, "PT1H", durationValue.String())
+
+	uuidValue, err := newTestParseNode(t, "8f841f30-e6e3-439a-a812-ebd369559c36").GetUUIDValue()
+	assert.Nil(t, err)
+	assert.Equal(t, uuid.MustParse("8f841f30-e6e3-439a-a812-ebd369559c36"), *uuidValue)
+
+	byteArrayValue, err := newTestParseNode(t, "AQI=").GetByteArrayValue()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2}, byteArrayValue)
+
+	enumValue, err := newTestParseNode(t, "suspended").GetEnumValue(internal.ParsePersonStatus)
+	assert.Nil(t, err)
+	assert.Equal(t, internal.SUSPENDED, *(enumValue.(*internal.PersonStatus)))
+}
+
+func TestTextParseNodeReadsNullValues(t *testing.T) {
+	node := newTestParseNode(t, "null")
+	stringValue, err := node.GetStringValue()
+	assert.Nil(t, err)
+	assert.Nil(t, stringValue)
+	int32Value, err := node.GetInt32Value()
+	assert.Nil(t, err)
+	assert.Nil(t, int32Value)
+}
+
+func TestTextParseNodeReturnsParsingErrors(t *testing.T) {
+	_, err := newTestParseNode(t, "not a number").GetInt32Value()
+	assert.NotNil(t, err)
+}
+
+func TestTextParseNodeRejectsStructuredData(t *testing.T) {
+	node := newTestParseNode(t, "text")
+	_, err := node.GetChildNode("key")
+	assert.Equal(t, ErrNoStructuredData, err)
+	_, err = node.GetObjectValue(internal.CreatePersonFromDiscriminatorValue)
+	assert.Equal(t, ErrNoStructuredData, err)
+	_, err = node.GetCollectionOfObjectValues(internal.CreatePersonFromDiscriminatorValue)
+	assert.Equal(t, ErrNoStructuredData, err)
+	_, err = node.GetCollectionOfPrimitiveValues("string")
+	assert.Equal(t, ErrNoStructuredData, err)
+	_, err = node.GetCollectionOfEnumValues(internal.ParsePersonStatus)
+	assert.Equal(t, ErrNoStructuredData, err)
+}
+
+func TestTextParseNodeFactory(t *testing.T) {
+	factory := NewTextParseNodeFactory()
+	contentType, err := factory.GetValidContentType()
+	assert.Nil(t, err)
+	assert.Equal(t, "text/plain", contentType)
+
+	node, err := factory.GetRootParseNode("text/plain", []byte("value"))
+	assert.Nil(t, err)
+	value, err := node.GetStringValue()
+	assert.Nil(t, err)
+	assert.Equal(t, "value", *value)
+
+	_, err = factory.GetRootParseNode("application/json", []byte("value"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,341 @@
+package text
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+)
+
+// TextSerializationWriter implements SerializationWriter for plain text content.
+// Only a single unnamed primitive value can be written, objects and collections return an error.
+type TextSerializationWriter struct {
+	builder                    strings.Builder
+	written                    bool
+	onBeforeAssignFieldValues  absser.ParsableAction
+	onAfterAssignFieldValues   absser.ParsableAction
+	onStartObjectSerialization absser.ParsableWriter
+}
+
+// ErrNoStructuredData is returned when structured data (keys, objects or collections) is written to or read from plain text content.
+var ErrNoStructuredData = errors.New("text does not support structured data")
+
+// ErrValueAlreadyWritten is returned when more than one value is written to a TextSerializationWriter.
+var ErrValueAlreadyWritten = errors.New("a value was already written for this serialization writer, text content only supports a single value")
+
+// NewTextSerializationWriter creates a new instance of the TextSerializationWriter.
+func NewTextSerializationWriter() *TextSerializationWriter {
+	return &TextSerializationWriter{}
+}
+
+func (w *TextSerializationWriter) writeValue(key string, value string) error {
+	if key != "" {
+		return ErrNoStructuredData
+	}
+	if w.written {
+		return ErrValueAlreadyWritten
+	}
+	w.written = true
+	w.builder.WriteString(value)
+	return nil
+}
+
+func writePointer[T any](w *TextSerializationWriter, key string, value *T, format func(T) string) error {
+	if value == nil {
+		return nil
+	}
+	return w.writeValue(key, format(*value))
+}
+
+func formatInt[T int8 | int32 | int64](value T) string {
+	return strconv.FormatInt(int64(value), 10)
+}
+
+// WriteStringValue writes a String value to underlying the byte array.
+func (w *TextSerializationWriter) WriteStringValue(key string, value *string) error {
+	return writePointer(w, key, value, func(v string) string { return v })
+}
+
+// WriteBoolValue writes a Bool value to underlying the byte array.
+func (w *TextSerializationWriter) WriteBoolValue(key string, value *bool) error {
+	return writePointer(w, key, value, strconv.FormatBool)
+}
+
+// WriteInt8Value writes a int8 value to underlying the byte array.
+func (w *TextSerializationWriter) WriteInt8Value(key string, value *int8) error {
+	return writePointer(w, key, value, formatInt[int8])
+}
+
+// WriteByteValue writes a Byte value to underlying the byte array.
+func (w *TextSerializationWriter) WriteByteValue(key string, value *byte) error {
+	return writePointer(w, key, value, func(v byte) string { return strconv.FormatUint(uint64(v), 10) })
+}
+
+// WriteInt32Value writes a Int32 value to underlying the byte array.
+func (w *TextSerializationWriter) WriteInt32Value(key string, value *int32) error {
+	return writePointer(w, key, value, formatInt[int32])
+}
+
+// WriteInt64Value writes a Int64 value to underlying the byte array.
+func (w *TextSerializationWriter) WriteInt64Value(key string, value *int64) error {
+	return writePointer(w, key, value, formatInt[int64])
+}
+
+// WriteFloat32Value writes a Float32 value to underlying the byte array.
+func (w *TextSerializationWriter) WriteFloat32Value(key string, value *float32) error {
+	return writePointer(w, key, value, func(v float32) string { return strconv.FormatFloat(float64(v), 'f', -1, 32) })
+}
+
+// WriteFloat64Value writes a Float64 value to underlying the byte array.
+func (w *TextSerializationWriter) WriteFloat64Value(key string, value *float64) error {
+	return writePointer(w, key, value, func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) })
+}
+
+// WriteByteArrayValue writes a ByteArray value to underlying the byte array.
+func (w *TextSerializationWriter) WriteByteArrayValue(key string, value []byte) error {
+	if value == nil {
+		return nil
+	}
+	return w.writeValue(key, base64.StdEncoding.EncodeToString(value))
+}
+
+// WriteTimeValue writes a Time value to underlying the byte array.
+func (w *TextSerializationWriter) WriteTimeValue(key string, value *time.Time) error {
+	return writePointer(w, key, value, func(v time.Time) string { return v.Format(time.RFC3339Nano) })
+}
+
+// WriteTimeOnlyValue writes the time part of a Time value to underlying the byte array.
+func (w *TextSerializationWriter) WriteTimeOnlyValue(key string, value *absser.TimeOnly) error {
+	return writePointer(w, key, value, absser.TimeOnly.String)
+}
+
+// WriteDateOnlyValue writes the date part of a Time value to underlying the byte array.
+func (w *TextSerializationWriter) WriteDateOnlyValue(key string, value *absser.DateOnly) error {
+	return writePointer(w, key, value, absser.DateOnly.String)
+}
+
+// WriteISODurationValue writes a ISODuration value to underlying the byte array.
+func (w *TextSerializationWriter) WriteISODurationValue(key string, value *absser.ISODuration) error {
+	return writePointer(w, key, value, absser.ISODuration.String)
+}
+
+// WriteUUIDValue writes a UUID value to underlying the byte array.
+func (w *TextSerializationWriter) WriteUUIDValue(key string, value *uuid.UUID) error {
+	return writePointer(w, key, value, uuid.UUID.String)
+}
+
+// WriteObjectValue returns an error as text content does not support objects.
+// Untyped primitive values are written as text.
+func (w *TextSerializationWriter) WriteObjectValue(key string, item absser.Parsable, additionalValuesToMerge ...absser.Parsable) error {
+	if untyped, ok := item.(absser.UntypedNodeable); ok && key == "" && len(additionalValuesToMerge) == 0 {
+		if _, isObject := untyped.(*absser.UntypedObject); !isObject {
+			return absser.WriteUntypedValue(w, key, untyped)
+		}
+	}
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfObjectValues returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfObjectValues(key string, collection []absser.Parsable) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfStringValues returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfStringValues(key string, collection []string) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfBoolValues returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfBoolValues(key string, collection []bool) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfInt8Values returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfInt8Values(key string, collection []int8) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfByteValues returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfByteValues(key string, collection []byte) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfInt32Values returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfInt32Values(key string, collection []int32) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfInt64Values returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfInt64Values(key string, collection []int64) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfFloat32Values returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfFloat32Values(key string, collection []float32) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfFloat64Values returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfFloat64Values(key string, collection []float64) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfTimeValues returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfTimeValues(key string, collection []time.Time) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfISODurationValues returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfISODurationValues(key string, collection []absser.ISODuration) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfDateOnlyValues returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfDateOnlyValues(key string, collection []absser.DateOnly) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfTimeOnlyValues returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfTimeOnlyValues(key string, collection []absser.TimeOnly) error {
+	return ErrNoStructuredData
+}
+
+// WriteCollectionOfUUIDValues returns an error as text content does not support collections.
+func (w *TextSerializationWriter) WriteCollectionOfUUIDValues(key string, collection []uuid.UUID) error {
+	return ErrNoStructuredData
+}
+
+// GetSerializedContent returns the resulting byte array from the serialization writer.
+func (w *TextSerializationWriter) GetSerializedContent() ([]byte, error) {
+	return []byte(w.builder.String()), nil
+}
+
+// WriteNullValue writes a null value.
+func (w *TextSerializationWriter) WriteNullValue(key string) error {
+	return w.writeValue(key, "null")
+}
+
+// WriteAdditionalData returns an error as text content does not support structured data.
+func (w *TextSerializationWriter) WriteAdditionalData(value map[string]interface{}) error {
+	if len(value) == 0 {
+		return nil
+	}
+	return ErrNoStructuredData
+}
+
+// WriteAnyValue writes the primitive value of unknown type.
+// Objects and collections return an error.
+func (w *TextSerializationWriter) WriteAnyValue(key string, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return w.WriteNullValue(key)
+	case string:
+		return w.WriteStringValue(key, &v)
+	case *string:
+		return w.WriteStringValue(key, v)
+	case bool:
+		return w.WriteBoolValue(key, &v)
+	case *bool:
+		return w.WriteBoolValue(key, v)
+	case byte:
+		return w.WriteByteValue(key, &v)
+	case *byte:
+		return w.WriteByteValue(key, v)
+	case int8:
+		return w.WriteInt8Value(key, &v)
+	case *int8:
+		return w.WriteInt8Value(key, v)
+	case int32:
+		return w.WriteInt32Value(key, &v)
+	case *int32:
+		return w.WriteInt32Value(key, v)
+	case int64:
+		return w.WriteInt64Value(key, &v)
+	case *int64:
+		return w.WriteInt64Value(key, v)
+	case int:
+		cast := int64(v)
+		return w.WriteInt64Value(key, &cast)
+	case *int:
+		if v == nil {
+			return w.WriteInt64Value(key, nil)
+		}
+		cast := int64(*v)
+		return w.WriteInt64Value(key, &cast)
+	case float32:
+		return w.WriteFloat32Value(key, &v)
+	case *float32:
+		return w.WriteFloat32Value(key, v)
+	case float64:
+		return w.WriteFloat64Value(key, &v)
+	case *float64:
+		return w.WriteFloat64Value(key, v)
+	case []byte:
+		return w.WriteByteArrayValue(key, v)
+	case time.Time:
+		return w.WriteTimeValue(key, &v)
+	case *time.Time:
+		return w.WriteTimeValue(key, v)
+	case absser.TimeOnly:
+		return w.WriteTimeOnlyValue(key, &v)
+	case *absser.TimeOnly:
+		return w.WriteTimeOnlyValue(key, v)
+	case absser.DateOnly:
+		return w.WriteDateOnlyValue(key, &v)
+	case *absser.DateOnly:
+		return w.WriteDateOnlyValue(key, v)
+	case absser.ISODuration:
+		return w.WriteISODurationValue(key, &v)
+	case *absser.ISODuration:
+		return w.WriteISODurationValue(key, v)
+	case uuid.UUID:
+		return w.WriteUUIDValue(key, &v)
+	case *uuid.UUID:
+		return w.WriteUUIDValue(key, v)
+	}
+	return ErrNoStructuredData
+}
+
+// GetOnBeforeSerialization returns a callback invoked before the serialization process starts.
+func (w *TextSerializationWriter) GetOnBeforeSerialization() absser.ParsableAction {
+	return w.onBeforeAssignFieldValues
+}
+
+// SetOnBeforeSerialization sets a callback invoked before the serialization process starts.
+func (w *TextSerializationWriter) SetOnBeforeSerialization(action absser.ParsableAction) error {
+	w.onBeforeAssignFieldValues = action
+	return nil
+}
+
+// GetOnAfterObjectSerialization returns a callback invoked after the serialization process completes.
+func (w *TextSerializationWriter) GetOnAfterObjectSerialization() absser.ParsableAction {
+	return w.onAfterAssignFieldValues
+}
+
+// SetOnAfterObjectSerialization sets a callback invoked after the serialization process completes.
+func (w *TextSerializationWriter) SetOnAfterObjectSerialization(action absser.ParsableAction) error {
+	w.onAfterAssignFieldValues = action
+	return nil
+}
+
+// GetOnStartObjectSerialization returns a callback invoked right after the serialization process starts.
+func (w *TextSerializationWriter) GetOnStartObjectSerialization() absser.ParsableWriter {
+	return w.onStartObjectSerialization
+}
+
+// SetOnStartObjectSerialization sets a callback invoked right after the serialization process starts.
+func (w *TextSerializationWriter) SetOnStartObjectSerialization(writer absser.ParsableWriter) error {
+	w.onStartObjectSerialization = writer
+	return nil
+}
+
+// Close clears the internal buffer.
+func (w *TextSerializationWriter) Close() error {
+	w.builder.Reset()
+	w.written = false
+	return nil
+}
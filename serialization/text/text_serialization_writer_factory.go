@@ -0,0 +1,40 @@
+package text
+
+import (
+	"errors"
+	"strings"
+
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+)
+
+// TextContentType is the content type handled by the text serialization writer and parse node factories.
+const TextContentType = "text/plain"
+
+// TextSerializationWriterFactory implements SerializationWriterFactory for plain text content.
+type TextSerializationWriterFactory struct {
+}
+
+// NewTextSerializationWriterFactory creates a new instance of the TextSerializationWriterFactory.
+func NewTextSerializationWriterFactory() *TextSerializationWriterFactory {
+	return &TextSerializationWriterFactory{}
+}
+
+// GetValidContentType returns the valid content type for the SerializationWriterFactoryRegistry
+func (f *TextSerializationWriterFactory) GetValidContentType() (string, error) {
+	return TextContentType, nil
+}
+
+// GetSerializationWriter returns the relevant SerializationWriter instance for the given content type
+func (f *TextSerializationWriterFactory) GetSerializationWriter(contentType string) (absser.SerializationWriter, error) {
+	if contentType == "" {
+		return nil, errors.New("the content type is empty")
+	} else if mediaType(contentType) != TextContentType {
+		return nil, errors.New("the provided content type is not supported")
+	}
+	return NewTextSerializationWriter(), nil
+}
+
+// mediaType returns the content type without its parameters.
+func mediaType(contentType string) string {
+	return strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+}
@@ -0,0 +1,100 @@
+package text
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextSerializationWriterHonoursInterface(t *testing.T) {
+	assert.Implements(t, (*absser.SerializationWriter)(nil), NewTextSerializationWriter())
+}
+
+func TestTextSerializationWriterWritesPrimitives(t *testing.T) {
+	value := "text"
+	boolValue := true
+	int8Value := int8(-8)
+	byteValue := byte(8)
+	int32Value := int32(32)
+	int64Value := int64(64)
+	float32Value := float32(1.5)
+	float64Value := 2.25
+	timeValue := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	id := uuid.MustParse("8f841f30-e6e3-439a-a812-ebd369559c36")
+	cases := map[string]func(w *TextSerializationWriter) error{
+		"text":                 func(w *TextSerializationWriter) error { return w.WriteStringValue("", &value) },
+		"true":                 func(w *TextSerializationWriter) error { return w.WriteBoolValue("", &boolValue) },
+		"-8":                   func(w *TextSerializationWriter) error { return w.WriteInt8Value("", &int8Value) },
+		"8":                    func(w *TextSerializationWriter) error { return w.WriteByteValue("", &byteValue) },
+		"32":                   func(w *TextSerializationWriter) error { return w.WriteInt32Value("", &int32Value) },
+		"64":                   func(w *TextSerializationWriter) error { return w.WriteInt64Value("", &int64Value) },
+		"1.5":                  func(w *TextSerializationWriter) error { return w.WriteFloat32Value("", &float32Value) },
+		"2.25":                 func(w *TextSerializationWriter) error { return w.WriteFloat64Value("", &float64Value) },
+		"2024-01-02T03:04:05Z": func(w *TextSerializationWriter) error { return w.WriteTimeValue("", &timeValue) },
+		"2024-01-02":           func(w *TextSerializationWriter) error { return w.WriteDateOnlyValue("", absser.NewDateOnly(timeValue)) },
+		"03:04:05":             func(w *TextSerializationWriter) error { return w.WriteTimeOnlyValue("", absser.NewTimeOnly(timeValue)) },
+		"PT1H": func(w *TextSerializationWriter) error {
+			return w.WriteISODurationValue("", absser.NewDuration(0, 0, 0, 1, 0, 0, 0))
+		},
+		"8f841f30-e6e3-439a-a812-ebd369559c36": func(w *TextSerializationWriter) error { return w.WriteUUIDValue("", &id) },
+		"AQI=":                                 func(w *TextSerializationWriter) error { return w.WriteByteArrayValue("", []byte{1, 2}) },
+		"null":                                 func(w *TextSerializationWriter) error { return w.WriteNullValue("") },
+		"42":                                   func(w *TextSerializationWriter) error { return w.WriteAnyValue("", 42) },
+	}
+	for expected, write := range cases {
+		writer := NewTextSerializationWriter()
+		assert.Nil(t, write(writer), expected)
+		content, err := writer.GetSerializedContent()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, string(content))
+	}
+}
+
+func TestTextSerializationWriterRejectsStructuredData(t *testing.T) {
+	value := "text"
+	writer := NewTextSerializationWriter()
+	assert.Equal(t, ErrNoStructuredData, writer.WriteStringValue("key", &value))
+	assert.Equal(t, ErrNoStructuredData, writer.WriteObjectValue("", nil))
+	assert.Equal(t, ErrNoStructuredData, writer.WriteCollectionOfObjectValues("", nil))
+	assert.Equal(t, ErrNoStructuredData, writer.WriteCollectionOfStringValues("", []string{"a"}))
+	assert.Equal(t, ErrNoStructuredData, writer.WriteCollectionOfInt32Values("", []int32{1}))
+	assert.Equal(t, ErrNoStructuredData, writer.WriteAdditionalData(map[string]interface{}{"a": "b"}))
+	assert.Equal(t, ErrNoStructuredData, writer.WriteAnyValue("", map[string]interface{}{}))
+}
+
+func TestTextSerializationWriterRejectsMultipleValues(t *testing.T) {
+	value := "text"
+	writer := NewTextSerializationWriter()
+	assert.Nil(t, writer.WriteStringValue("", &value))
+	assert.Equal(t, ErrValueAlreadyWritten, writer.WriteStringValue("", &value))
+}
+
+func TestTextSerializationWriterFactory(t *testing.T) {
+	factory := NewTextSerializationWriterFactory()
+	contentType, err := factory.GetValidContentType()
+	assert.Nil(t, err)
+	assert.Equal(t, "text/plain", contentType)
+
+	writer, err := factory.GetSerializationWriter("text/plain; charset=utf-8")
+	assert.Nil(t, err)
+	assert.NotNil(t, writer)
+
+	_, err = factory.GetSerializationWriter("application/json")
+	assert.NotNil(t, err)
+	_, err = factory.GetSerializationWriter("")
+	assert.NotNil(t, err)
+}
+
+func TestTextSerializationWriterWritesUntypedPrimitives(t *testing.T) {
+	writer := NewTextSerializationWriter()
+	assert.Nil(t, writer.WriteObjectValue("", absser.NewUntypedLong(9007199254740993)))
+	content, err := writer.GetSerializedContent()
+	assert.Nil(t, err)
+	assert.Equal(t, "9007199254740993", string(content))
+
+	writer = NewTextSerializationWriter()
+	assert.Equal(t, ErrNoStructuredData, writer.WriteObjectValue("", absser.NewUntypedObject(map[string]absser.UntypedNodeable{})))
+}
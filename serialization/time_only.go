@@ -1,6 +1,8 @@
 package serialization
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -83,3 +85,115 @@ func DetectPrecision(t time.Time) int {
 	}
 	return len(strings.TrimRight(fmt.Sprintf("%09d", nanos), "0"))
 }
+
+// clock returns the time of the day on the zero date in UTC.
+func (t TimeOnly) clock() time.Time {
+	hour, minute, second := t.time.Clock()
+	return time.Date(0, time.January, 1, hour, minute, second, t.time.Nanosecond(), time.UTC)
+}
+
+// Clock returns the hour, minute and second of the time.
+func (t TimeOnly) Clock() (hour, minute, second int) {
+	return t.time.Clock()
+}
+
+// Nanosecond returns the nanosecond offset within the second.
+func (t TimeOnly) Nanosecond() int {
+	return t.time.Nanosecond()
+}
+
+// OnDate returns the time on the date in the location, UTC is used when the location is nil.
+func (t TimeOnly) OnDate(date DateOnly, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	year, month, day := date.Date()
+	hour, minute, second := t.time.Clock()
+	return time.Date(year, month, day, hour, minute, second, t.time.Nanosecond(), loc)
+}
+
+// Add returns the time shifted by the duration, wrapping around midnight.
+func (t TimeOnly) Add(d time.Duration) TimeOnly {
+	day := 24 * time.Hour
+	offset := (t.clock().Sub(time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)) + d%day + day) % day
+	return TimeOnly{time: time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC).Add(offset)}
+}
+
+// Sub returns the duration from the other time to the time, which is negative when the other time is later in the day.
+func (t TimeOnly) Sub(other TimeOnly) time.Duration {
+	return t.clock().Sub(other.clock())
+}
+
+// Compare returns -1, 0 or 1 when the time is respectively before, equal or after the other time of the day.
+func (t TimeOnly) Compare(other TimeOnly) int {
+	return t.clock().Compare(other.clock())
+}
+
+// Before returns whether the time is earlier in the day than the other time.
+func (t TimeOnly) Before(other TimeOnly) bool {
+	return t.Compare(other) < 0
+}
+
+// After returns whether the time is later in the day than the other time.
+func (t TimeOnly) After(other TimeOnly) bool {
+	return t.Compare(other) > 0
+}
+
+// Equal returns whether both values represent the same time of the day, regardless of the date and location of the underlying time.
+func (t TimeOnly) Equal(other TimeOnly) bool {
+	return t.Compare(other) == 0
+}
+
+// MarshalText implements encoding.TextMarshaler, fractional seconds are kept.
+func (t TimeOnly) MarshalText() ([]byte, error) {
+	return []byte(t.StringWithPrecision(DetectPrecision(t.time))), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, an empty text results in the zero value.
+func (t *TimeOnly) UnmarshalText(text []byte) error {
+	value, err := ParseTimeOnly(string(text))
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		*t = TimeOnly{}
+	} else {
+		*t = *value
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, fractional seconds are kept.
+func (t TimeOnly) MarshalJSON() ([]byte, error) {
+	text, _ := t.MarshalText()
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, null leaves the value unchanged.
+func (t *TimeOnly) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, t)
+}
+
+// Value implements driver.Valuer, the time is stored as text.
+func (t TimeOnly) Value() (driver.Value, error) {
+	text, _ := t.MarshalText()
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner, it accepts text and time values, null results in the zero value.
+func (t *TimeOnly) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*t = TimeOnly{}
+		return nil
+	case time.Time:
+		*t = TimeOnly{time: value}
+		return nil
+	}
+	return scanText(src, "TimeOnly", t)
+}
+
+// Set implements flag.Value.
+func (t *TimeOnly) Set(value string) error {
+	return t.UnmarshalText([]byte(value))
+}
@@ -1,10 +1,25 @@
 package serialization
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"flag"
 	"testing"
 	"time"
 )
 
+var (
+	_ encoding.TextMarshaler   = TimeOnly{}
+	_ encoding.TextUnmarshaler = &TimeOnly{}
+	_ json.Marshaler           = TimeOnly{}
+	_ json.Unmarshaler         = &TimeOnly{}
+	_ driver.Valuer            = TimeOnly{}
+	_ sql.Scanner              = &TimeOnly{}
+	_ flag.Value               = &TimeOnly{}
+)
+
 func TestParseTimeOnly(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -304,3 +319,90 @@ func TestTimeOnly_StringWithPrecision_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeOnlyArithmetic(t *testing.T) {
+	timeOnly, _ := ParseTimeOnly("23:30:00")
+
+	if got := timeOnly.Add(time.Hour).String(); got != "00:30:00" {
+		t.Errorf("Add() wraps to %v, want 00:30:00", got)
+	}
+	if got := timeOnly.Add(-24*time.Hour - time.Minute).String(); got != "23:29:00" {
+		t.Errorf("Add() with a negative duration = %v, want 23:29:00", got)
+	}
+	earlier, _ := ParseTimeOnly("22:00:00.5")
+	if got := timeOnly.Sub(*earlier); got != 89*time.Minute+59*time.Second+500*time.Millisecond {
+		t.Errorf("Sub() = %v, want 1h29m59.5s", got)
+	}
+	if !earlier.Before(*timeOnly) || earlier.After(*timeOnly) || earlier.Compare(*timeOnly) != -1 {
+		t.Errorf("%v should be before %v", earlier, timeOnly)
+	}
+	if !timeOnly.Equal(*NewTimeOnly(time.Date(2020, 1, 1, 23, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60)))) {
+		t.Errorf("Equal() should ignore the date and location")
+	}
+}
+
+func TestTimeOnlyOnDate(t *testing.T) {
+	timeOnly, _ := ParseTimeOnly("15:04:05.123")
+	dateOnly, _ := ParseDateOnly("2024-01-04")
+	location := time.FixedZone("UTC+2", 2*60*60)
+
+	want := time.Date(2024, 1, 4, 15, 4, 5, 123000000, location)
+	if got := timeOnly.OnDate(*dateOnly, location); !got.Equal(want) || got.Location() != location {
+		t.Errorf("OnDate() = %v, want %v", got, want)
+	}
+}
+
+func TestTimeOnlyJson(t *testing.T) {
+	type model struct {
+		Time     TimeOnly  `json:"time"`
+		Optional *TimeOnly `json:"optional"`
+	}
+	timeOnly, _ := ParseTimeOnly("15:04:05.12")
+
+	content, err := json.Marshal(model{Time: *timeOnly})
+	if err != nil || string(content) != `{"time":"15:04:05.12","optional":null}` {
+		t.Errorf("json.Marshal() = %s, %v", content, err)
+	}
+
+	var result model
+	if err := json.Unmarshal([]byte(`{"time":"01:02:03","optional":"04:05:06"}`), &result); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if result.Time.String() != "01:02:03" || result.Optional.String() != "04:05:06" {
+		t.Errorf("json.Unmarshal() = %v, %v", result.Time, result.Optional)
+	}
+	if err := json.Unmarshal([]byte(`{"time":"25:00:00"}`), &result); err == nil {
+		t.Errorf("json.Unmarshal() should fail for an invalid time")
+	}
+}
+
+func TestTimeOnlySql(t *testing.T) {
+	timeOnly, _ := ParseTimeOnly("15:04:05.5")
+	if value, err := timeOnly.Value(); err != nil || value != "15:04:05.5" {
+		t.Errorf("Value() = %v, %v", value, err)
+	}
+
+	var result TimeOnly
+	if err := result.Scan([]byte("01:02:03")); err != nil || result.String() != "01:02:03" {
+		t.Errorf("Scan() = %v, %v", result, err)
+	}
+	if err := result.Scan(time.Date(2024, 1, 4, 4, 5, 6, 0, time.UTC)); err != nil || result.String() != "04:05:06" {
+		t.Errorf("Scan() = %v, %v", result, err)
+	}
+	if err := result.Scan(nil); err != nil || result != (TimeOnly{}) {
+		t.Errorf("Scan(nil) = %v, %v", result, err)
+	}
+	if err := result.Scan(1.5); err == nil || err.Error() != "cannot scan a value of type float64 into a TimeOnly" {
+		t.Errorf("Scan() error = %v", err)
+	}
+}
+
+func TestTimeOnlyFlag(t *testing.T) {
+	var timeOnly TimeOnly
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Var(&timeOnly, "time", "a time")
+
+	if err := flags.Parse([]string{"-time", "08:30:00"}); err != nil || timeOnly.String() != "08:30:00" {
+		t.Errorf("flag parsing = %v, %v", timeOnly, err)
+	}
+}
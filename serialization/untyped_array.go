@@ -7,7 +7,22 @@ type UntypedArray struct {
 
 // GetValue returns a collection of UntypedNode.
 func (un *UntypedArray) GetValue() []UntypedNodeable {
-	return un.value.([]UntypedNodeable)
+	castValue, ok := un.value.([]UntypedNodeable)
+	if ok {
+		return castValue
+	}
+	return nil
+}
+
+// Serialize returns an error as the value has no properties, write it with WriteUntypedValue instead of WriteObjectValue.
+func (un *UntypedArray) Serialize(writer SerializationWriter) error {
+	return errUntypedValueWithoutProperties(un)
+}
+
+// ToValue returns the collection as a slice of plain Go values, nested nodes failing to convert become nil, see UntypedToValue.
+func (un *UntypedArray) ToValue() any {
+	value, _ := UntypedToValue(un)
+	return value
 }
 
 // NewUntypedArray creates a new UntypedArray object.
@@ -14,6 +14,19 @@ func (un *UntypedBoolean) GetValue() *bool {
 	return nil
 }
 
+// Serialize returns an error as the value has no properties, write it with WriteUntypedValue instead of WriteObjectValue.
+func (un *UntypedBoolean) Serialize(writer SerializationWriter) error {
+	return errUntypedValueWithoutProperties(un)
+}
+
+// ToValue returns the bool value, or nil when the node has no value.
+func (un *UntypedBoolean) ToValue() any {
+	if value := un.GetValue(); value != nil {
+		return *value
+	}
+	return nil
+}
+
 // NewUntypedBoolean creates a new UntypedBoolean object.
 func NewUntypedBoolean(boolValue bool) *UntypedBoolean {
 	m := &UntypedBoolean{}
@@ -14,6 +14,19 @@ func (un *UntypedDouble) GetValue() *float64 {
 	return nil
 }
 
+// Serialize returns an error as the value has no properties, write it with WriteUntypedValue instead of WriteObjectValue.
+func (un *UntypedDouble) Serialize(writer SerializationWriter) error {
+	return errUntypedValueWithoutProperties(un)
+}
+
+// ToValue returns the float64 value, or nil when the node has no value.
+func (un *UntypedDouble) ToValue() any {
+	if value := un.GetValue(); value != nil {
+		return *value
+	}
+	return nil
+}
+
 // NewUntypedDouble creates a new UntypedDouble object.
 func NewUntypedDouble(float64Value float64) *UntypedDouble {
 	m := &UntypedDouble{}
@@ -14,6 +14,19 @@ func (un *UntypedFloat) GetValue() *float32 {
 	return nil
 }
 
+// Serialize returns an error as the value has no properties, write it with WriteUntypedValue instead of WriteObjectValue.
+func (un *UntypedFloat) Serialize(writer SerializationWriter) error {
+	return errUntypedValueWithoutProperties(un)
+}
+
+// ToValue returns the float32 value, or nil when the node has no value.
+func (un *UntypedFloat) ToValue() any {
+	if value := un.GetValue(); value != nil {
+		return *value
+	}
+	return nil
+}
+
 // NewUntypedFloat creates a new UntypedFloat object.
 func NewUntypedFloat(float32Value float32) *UntypedFloat {
 	m := &UntypedFloat{}
@@ -14,6 +14,19 @@ func (un *UntypedInteger) GetValue() *int32 {
 	return nil
 }
 
+// Serialize returns an error as the value has no properties, write it with WriteUntypedValue instead of WriteObjectValue.
+func (un *UntypedInteger) Serialize(writer SerializationWriter) error {
+	return errUntypedValueWithoutProperties(un)
+}
+
+// ToValue returns the int32 value, or nil when the node has no value.
+func (un *UntypedInteger) ToValue() any {
+	if value := un.GetValue(); value != nil {
+		return *value
+	}
+	return nil
+}
+
 // NewUntypedInteger creates a new UntypedInteger object.
 func NewUntypedInteger(int32Value int32) *UntypedInteger {
 	m := &UntypedInteger{}
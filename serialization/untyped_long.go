@@ -14,6 +14,19 @@ func (un *UntypedLong) GetValue() *int64 {
 	return nil
 }
 
+// Serialize returns an error as the value has no properties, write it with WriteUntypedValue instead of WriteObjectValue.
+func (un *UntypedLong) Serialize(writer SerializationWriter) error {
+	return errUntypedValueWithoutProperties(un)
+}
+
+// ToValue returns the int64 value, or nil when the node has no value.
+func (un *UntypedLong) ToValue() any {
+	if value := un.GetValue(); value != nil {
+		return *value
+	}
+	return nil
+}
+
 // NewUntypedLong creates a new UntypedLong object.
 func NewUntypedLong(int64Value int64) *UntypedLong {
 	m := &UntypedLong{}
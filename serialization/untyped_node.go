@@ -1,10 +1,30 @@
 package serialization
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// UntypedNodeable is implemented by the nodes of an untyped payload.
+// Only UntypedObject serializes properties, the other nodes return an error from Serialize and are written with WriteUntypedValue,
+// which only relies on the primitives of the SerializationWriter and works with any writer.
 type UntypedNodeable interface {
 	Parsable
 	GetIsUntypedNode() bool
 }
 
+// UntypedValueConverter is implemented by the untyped nodes converting themselves to plain Go values, see UntypedToValue.
+type UntypedValueConverter interface {
+	UntypedNodeable
+	// ToValue returns the node as plain Go values, map[string]any for objects, []any for collections and primitive values.
+	ToValue() any
+}
+
 // Base model for an untyped object.
 type UntypedNode struct {
 	value any
@@ -20,10 +40,17 @@ func (m *UntypedNode) GetValue() any {
 	return m.value
 }
 
+// ToValue returns the underlying value, pointers are dereferenced.
+func (m *UntypedNode) ToValue() any {
+	return dereferenceNative(m.value)
+}
+
 // Serialize writes the objects properties to the current writer.
 func (m *UntypedNode) Serialize(writer SerializationWriter) error {
-	// Serialize the entity
-	return nil
+	if m.value == nil {
+		return nil
+	}
+	return writer.WriteAnyValue("", m.value)
 }
 
 // GetFieldDeserializers returns the deserialization information for this object.
@@ -42,3 +69,324 @@ func NewUntypedNode(value any) *UntypedNode {
 func CreateUntypedNodeFromDiscriminatorValue(parseNode ParseNode) (Parsable, error) {
 	return NewUntypedNode(nil), nil
 }
+
+// UntypedFromValue builds an untyped node tree from plain Go values.
+// Maps with string keys become UntypedObject, slices and arrays become UntypedArray, nil becomes UntypedNull
+// and models are converted through their serialization.
+func UntypedFromValue(value any) (UntypedNodeable, error) {
+	// typed nil pointers are checked first as the methods of the interfaces below may have value receivers
+	if reflected := reflect.ValueOf(value); reflected.Kind() == reflect.Pointer && reflected.IsNil() {
+		return NewUntypedNull(), nil
+	}
+	switch v := value.(type) {
+	case nil:
+		return NewUntypedNull(), nil
+	case UntypedNodeable:
+		if isNilParsable(v) {
+			return NewUntypedNull(), nil
+		}
+		return v, nil
+	case Parsable:
+		if isNilParsable(v) {
+			return NewUntypedNull(), nil
+		}
+		native, err := ToNative(v)
+		if err != nil {
+			return nil, err
+		}
+		return UntypedFromValue(native)
+	case string:
+		return NewUntypedString(v), nil
+	case bool:
+		return NewUntypedBoolean(v), nil
+	case int8:
+		return NewUntypedInteger(int32(v)), nil
+	case int16:
+		return NewUntypedInteger(int32(v)), nil
+	case int32:
+		return NewUntypedInteger(v), nil
+	case uint8:
+		return NewUntypedInteger(int32(v)), nil
+	case uint16:
+		return NewUntypedInteger(int32(v)), nil
+	case int:
+		return NewUntypedLong(int64(v)), nil
+	case int64:
+		return NewUntypedLong(v), nil
+	case uint32:
+		return NewUntypedLong(int64(v)), nil
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return nil, fmt.Errorf("value %d overflows int64", v)
+		}
+		return NewUntypedLong(int64(v)), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return nil, fmt.Errorf("value %d overflows int64", v)
+		}
+		return NewUntypedLong(int64(v)), nil
+	case float32:
+		return NewUntypedFloat(v), nil
+	case float64:
+		return NewUntypedDouble(v), nil
+	case json.Number:
+		if integer, err := v.Int64(); err == nil {
+			return NewUntypedLong(integer), nil
+		}
+		float, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return NewUntypedDouble(float), nil
+	case []byte:
+		return NewUntypedString(base64.StdEncoding.EncodeToString(v)), nil
+	case time.Time:
+		return NewUntypedString(v.Format(time.RFC3339Nano)), nil
+	case fmt.Stringer:
+		// DateOnly, TimeOnly, ISODuration, UUID and enums
+		return NewUntypedString(v.String()), nil
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if reflected.IsNil() {
+			return NewUntypedNull(), nil
+		}
+		return UntypedFromValue(reflected.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if reflected.Kind() == reflect.Slice && reflected.IsNil() {
+			return NewUntypedNull(), nil
+		}
+		collection := make([]UntypedNodeable, reflected.Len())
+		for i := range collection {
+			item, err := UntypedFromValue(reflected.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("could not convert item %d: %w", i, err)
+			}
+			collection[i] = item
+		}
+		return NewUntypedArray(collection), nil
+	case reflect.Map:
+		if reflected.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", reflected.Type().Key())
+		}
+		if reflected.IsNil() {
+			return NewUntypedNull(), nil
+		}
+		properties := make(map[string]UntypedNodeable, reflected.Len())
+		iterator := reflected.MapRange()
+		for iterator.Next() {
+			key := iterator.Key().String()
+			property, err := UntypedFromValue(iterator.Value().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("could not convert property %q: %w", key, err)
+			}
+			properties[key] = property
+		}
+		return NewUntypedObject(properties), nil
+	case reflect.String:
+		return NewUntypedString(reflected.String()), nil
+	case reflect.Bool:
+		return NewUntypedBoolean(reflected.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewUntypedLong(reflected.Int()), nil
+	case reflect.Float32, reflect.Float64:
+		return NewUntypedDouble(reflected.Float()), nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", value)
+}
+
+// UntypedToParsable decodes the untyped node tree into a model created by the factory.
+func UntypedToParsable(node UntypedNodeable, ctor ParsableFactory) (Parsable, error) {
+	value, err := UntypedToValue(node)
+	if err != nil {
+		return nil, err
+	}
+	return FromNative(value, ctor)
+}
+
+// UntypedToValue returns the untyped node tree as plain Go values, map[string]any for objects, []any for collections and primitive values.
+// Nodes implementing UntypedValueConverter convert themselves, the other ones are converted through their serialization.
+func UntypedToValue(node UntypedNodeable) (any, error) {
+	if isNilParsable(node) {
+		return nil, nil
+	}
+	switch value := node.(type) {
+	case *UntypedObject:
+		properties := value.GetValue()
+		if properties == nil {
+			return nil, nil
+		}
+		result := make(map[string]any, len(properties))
+		for key, property := range properties {
+			converted, err := UntypedToValue(property)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = converted
+		}
+		return result, nil
+	case *UntypedArray:
+		collection := value.GetValue()
+		if collection == nil {
+			return nil, nil
+		}
+		result := make([]any, len(collection))
+		for i, item := range collection {
+			converted, err := UntypedToValue(item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	case UntypedValueConverter:
+		return value.ToValue(), nil
+	}
+	return ToNative(node)
+}
+
+// WriteUntypedValue writes the untyped node for the given key using the writer primitives.
+// Objects and collections are written recursively and numeric values keep the precision of their node type.
+func WriteUntypedValue(writer SerializationWriter, key string, node UntypedNodeable) error {
+	if isNilParsable(node) {
+		return writer.WriteNullValue(key)
+	}
+	switch value := node.(type) {
+	case *UntypedNull:
+		return writer.WriteNullValue(key)
+	case *UntypedString:
+		return writeUntypedPrimitive(writer, key, value.GetValue(), writer.WriteStringValue)
+	case *UntypedBoolean:
+		return writeUntypedPrimitive(writer, key, value.GetValue(), writer.WriteBoolValue)
+	case *UntypedInteger:
+		return writeUntypedPrimitive(writer, key, value.GetValue(), writer.WriteInt32Value)
+	case *UntypedLong:
+		return writeUntypedPrimitive(writer, key, value.GetValue(), writer.WriteInt64Value)
+	case *UntypedFloat:
+		return writeUntypedPrimitive(writer, key, value.GetValue(), writer.WriteFloat32Value)
+	case *UntypedDouble:
+		return writeUntypedPrimitive(writer, key, value.GetValue(), writer.WriteFloat64Value)
+	case *UntypedArray:
+		if value.GetValue() == nil {
+			return writer.WriteNullValue(key)
+		}
+		return writeUntypedCollection(writer, key, value.GetValue())
+	case *UntypedObject:
+		if value.GetValue() == nil {
+			return writer.WriteNullValue(key)
+		}
+		return writer.WriteObjectValue(key, value)
+	case *UntypedNode:
+		if value.GetValue() == nil {
+			return writer.WriteNullValue(key)
+		}
+		return writer.WriteAnyValue(key, value.GetValue())
+	case UntypedValueConverter:
+		return writer.WriteAnyValue(key, value.ToValue())
+	}
+	// other implementations serialize themselves
+	return writer.WriteObjectValue(key, node)
+}
+
+func writeUntypedPrimitive[T any](writer SerializationWriter, key string, value *T, write func(string, *T) error) error {
+	if value == nil {
+		return writer.WriteNullValue(key)
+	}
+	return write(key, value)
+}
+
+// writeUntypedCollection writes the collection with the matching primitive collection writer when all the items share the same type,
+// as a collection of objects when all the items are objects and as a single value holding the items otherwise.
+func writeUntypedCollection(writer SerializationWriter, key string, collection []UntypedNodeable) error {
+	if len(collection) > 0 {
+		switch collection[0].(type) {
+		case *UntypedString:
+			if values, ok := untypedCollectionValues[*UntypedString](collection, (*UntypedString).GetValue); ok {
+				return writer.WriteCollectionOfStringValues(key, values)
+			}
+		case *UntypedBoolean:
+			if values, ok := untypedCollectionValues[*UntypedBoolean](collection, (*UntypedBoolean).GetValue); ok {
+				return writer.WriteCollectionOfBoolValues(key, values)
+			}
+		case *UntypedInteger:
+			if values, ok := untypedCollectionValues[*UntypedInteger](collection, (*UntypedInteger).GetValue); ok {
+				return writer.WriteCollectionOfInt32Values(key, values)
+			}
+		case *UntypedLong:
+			if values, ok := untypedCollectionValues[*UntypedLong](collection, (*UntypedLong).GetValue); ok {
+				return writer.WriteCollectionOfInt64Values(key, values)
+			}
+		case *UntypedFloat:
+			if values, ok := untypedCollectionValues[*UntypedFloat](collection, (*UntypedFloat).GetValue); ok {
+				return writer.WriteCollectionOfFloat32Values(key, values)
+			}
+		case *UntypedDouble:
+			if values, ok := untypedCollectionValues[*UntypedDouble](collection, (*UntypedDouble).GetValue); ok {
+				return writer.WriteCollectionOfFloat64Values(key, values)
+			}
+		}
+	}
+	if objects, ok := untypedObjects(collection); ok {
+		return writer.WriteCollectionOfObjectValues(key, objects)
+	}
+	// collections mixing types, containing nulls or containing other collections have no typed writer,
+	// their items are converted one by one to plain Go values written as a single value
+	items := make([]any, len(collection))
+	for i, item := range collection {
+		value, err := UntypedToValue(item)
+		if err != nil {
+			return err
+		}
+		items[i] = value
+	}
+	return writer.WriteAnyValue(key, items)
+}
+
+// untypedObjects returns the collection as models when it is not empty and all its items are objects.
+func untypedObjects(collection []UntypedNodeable) ([]Parsable, bool) {
+	if len(collection) == 0 {
+		return nil, false
+	}
+	objects := make([]Parsable, len(collection))
+	for i, item := range collection {
+		object, ok := item.(*UntypedObject)
+		if !ok || object == nil || object.GetValue() == nil {
+			return nil, false
+		}
+		objects[i] = object
+	}
+	return objects, true
+}
+
+// errUntypedValueWithoutProperties returns the error of the untyped nodes which are not objects and cannot serialize properties.
+func errUntypedValueWithoutProperties(node UntypedNodeable) error {
+	return fmt.Errorf("%T is not an object and has no properties to serialize, write it with WriteUntypedValue", node)
+}
+
+// untypedCollectionValues returns the primitive values of the collection when all the items are non nil nodes of type N.
+func untypedCollectionValues[N UntypedNodeable, T any](collection []UntypedNodeable, getValue func(N) *T) ([]T, bool) {
+	values := make([]T, len(collection))
+	for i, item := range collection {
+		node, ok := item.(N)
+		if !ok {
+			return nil, false
+		}
+		value := getValue(node)
+		if value == nil {
+			return nil, false
+		}
+		values[i] = *value
+	}
+	return values, true
+}
+
+// sortedUntypedKeys returns the keys of the properties in a stable order.
+func sortedUntypedKeys(properties map[string]UntypedNodeable) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
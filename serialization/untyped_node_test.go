@@ -0,0 +1,300 @@
+package serialization
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func newTestUntypedObject() *UntypedObject {
+	return NewUntypedObject(map[string]UntypedNodeable{
+		"name":     NewUntypedString("value"),
+		"enabled":  NewUntypedBoolean(true),
+		"count":    NewUntypedInteger(3),
+		"big":      NewUntypedLong(9007199254740993),
+		"ratio":    NewUntypedFloat(1.5),
+		"precise":  NewUntypedDouble(0.1),
+		"missing":  NewUntypedNull(),
+		"tags":     NewUntypedArray([]UntypedNodeable{NewUntypedString("a"), NewUntypedString("b")}),
+		"mixed":    NewUntypedArray([]UntypedNodeable{NewUntypedString("a"), NewUntypedInteger(1), NewUntypedNull(), NewUntypedArray([]UntypedNodeable{NewUntypedBoolean(false)})}),
+		"children": NewUntypedArray([]UntypedNodeable{NewUntypedObject(map[string]UntypedNodeable{"id": NewUntypedLong(1)})}),
+		"nested":   NewUntypedObject(map[string]UntypedNodeable{"inner": NewUntypedObject(map[string]UntypedNodeable{})}),
+	})
+}
+
+func TestUntypedObjectSerializesThroughWriterPrimitives(t *testing.T) {
+	result, err := ToNative(newTestUntypedObject())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{
+		"name":     "value",
+		"enabled":  true,
+		"count":    int32(3),
+		"big":      int64(9007199254740993),
+		"ratio":    float32(1.5),
+		"precise":  0.1,
+		"missing":  nil,
+		"tags":     []any{"a", "b"},
+		"mixed":    []any{"a", int32(1), nil, []any{false}},
+		"children": []any{map[string]any{"id": int64(1)}},
+		"nested":   map[string]any{"inner": map[string]any{}},
+	}, result)
+}
+
+func TestUntypedPrimitivesSerializeAsModelProperties(t *testing.T) {
+	writer := NewNativeSerializationWriter()
+	assert.Nil(t, writer.WriteObjectValue("value", NewUntypedDouble(2.5)))
+	assert.Nil(t, writer.WriteObjectValue("empty", NewUntypedNull()))
+	assert.Nil(t, writer.WriteObjectValue("items", NewUntypedArray([]UntypedNodeable{NewUntypedLong(1)})))
+	assert.Equal(t, map[string]any{"value": 2.5, "empty": nil, "items": []any{int64(1)}}, writer.GetValue())
+}
+
+func TestUntypedArraySerializesAtRoot(t *testing.T) {
+	writer := NewNativeSerializationWriter()
+	array := NewUntypedArray([]UntypedNodeable{NewUntypedInteger(1), NewUntypedInteger(2)})
+	assert.Nil(t, WriteUntypedValue(writer, "", array))
+	assert.Equal(t, []any{int32(1), int32(2)}, writer.GetValue())
+	// an array has no properties
+	assert.NotNil(t, array.Serialize(NewNativeSerializationWriter()))
+}
+
+// plainWriter is a SerializationWriter which does not know about untyped nodes, objects are serialized through their properties
+// and values cannot be written without a key inside an object, as with JSON.
+type plainWriter struct {
+	*NativeSerializationWriter
+}
+
+func newPlainWriter() *plainWriter {
+	return &plainWriter{NewNativeSerializationWriter()}
+}
+
+func (w *plainWriter) serializeProperties(item Parsable) (map[string]any, error) {
+	child := newPlainWriter()
+	child.current = make(map[string]any)
+	child.root = child.current
+	if err := item.Serialize(child); err != nil {
+		return nil, err
+	}
+	return child.current, nil
+}
+
+func (w *plainWriter) WriteObjectValue(key string, item Parsable, additionalValuesToMerge ...Parsable) error {
+	properties, err := w.serializeProperties(item)
+	if err != nil {
+		return err
+	}
+	return w.setValue(key, properties)
+}
+
+func (w *plainWriter) WriteCollectionOfObjectValues(key string, collection []Parsable) error {
+	result := make([]any, len(collection))
+	for i, item := range collection {
+		properties, err := w.serializeProperties(item)
+		if err != nil {
+			return err
+		}
+		result[i] = properties
+	}
+	return w.setValue(key, result)
+}
+
+func TestUntypedObjectSerializesWithWritersUnawareOfUntypedNodes(t *testing.T) {
+	writer := newPlainWriter()
+	assert.Nil(t, writer.WriteObjectValue("", newTestUntypedObject()))
+	assert.Equal(t, map[string]any{
+		"name":     "value",
+		"enabled":  true,
+		"count":    int32(3),
+		"big":      int64(9007199254740993),
+		"ratio":    float32(1.5),
+		"precise":  0.1,
+		"missing":  nil,
+		"tags":     []any{"a", "b"},
+		"mixed":    []any{"a", int32(1), nil, []any{false}},
+		"children": []any{map[string]any{"id": int64(1)}},
+		"nested":   map[string]any{"inner": map[string]any{}},
+	}, writer.GetValue())
+
+	writer = newPlainWriter()
+	assert.Nil(t, WriteUntypedValue(writer, "", NewUntypedArray([]UntypedNodeable{NewUntypedNull(), NewUntypedArray([]UntypedNodeable{NewUntypedLong(1)})})))
+	assert.Equal(t, []any{nil, []any{int64(1)}}, writer.GetValue())
+
+	// primitives have no properties, they are not written as empty keys
+	assert.NotNil(t, newPlainWriter().WriteObjectValue("value", NewUntypedString("a")))
+}
+
+func TestUntypedValuesAreWrittenWithWritersUnawareOfUntypedNodes(t *testing.T) {
+	cases := []struct {
+		node     UntypedNodeable
+		expected any
+	}{
+		{NewUntypedString("a"), "a"},
+		{NewUntypedBoolean(true), true},
+		{NewUntypedInteger(3), int32(3)},
+		{NewUntypedLong(9007199254740993), int64(9007199254740993)},
+		{NewUntypedFloat(1.5), float32(1.5)},
+		{NewUntypedDouble(0.1), 0.1},
+		{NewUntypedNull(), nil},
+		{NewUntypedArray([]UntypedNodeable{NewUntypedString("a"), NewUntypedString("b")}), []any{"a", "b"}},
+		{NewUntypedArray([]UntypedNodeable{NewUntypedObject(map[string]UntypedNodeable{"id": NewUntypedLong(1)}), NewUntypedNull()}), []any{map[string]any{"id": int64(1)}, nil}},
+	}
+	for _, c := range cases {
+		writer := newPlainWriter()
+		assert.Nil(t, writer.WriteObjectValue("", NewUntypedObject(map[string]UntypedNodeable{"value": c.node})))
+		assert.Equal(t, map[string]any{"value": c.expected}, writer.GetValue(), "%T", c.node)
+
+		writer = newPlainWriter()
+		assert.Nil(t, WriteUntypedValue(writer, "", c.node))
+		assert.Equal(t, c.expected, writer.GetValue(), "%T", c.node)
+
+		// the node has no properties, WriteObjectValue cannot write it without knowing about untyped nodes
+		assert.NotNil(t, c.node.Serialize(newPlainWriter()), "%T", c.node)
+	}
+}
+
+func TestUntypedObjectSerializesPropertiesInStableOrder(t *testing.T) {
+	writer := &keyRecordingWriter{NativeSerializationWriter: NewNativeSerializationWriter()}
+	err := NewUntypedObject(map[string]UntypedNodeable{
+		"b": NewUntypedString("b"),
+		"a": NewUntypedString("a"),
+		"c": NewUntypedString("c"),
+	}).Serialize(writer)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, writer.keys)
+}
+
+type keyRecordingWriter struct {
+	*NativeSerializationWriter
+	keys []string
+}
+
+func (w *keyRecordingWriter) WriteStringValue(key string, value *string) error {
+	w.keys = append(w.keys, key)
+	return w.NativeSerializationWriter.WriteStringValue(key, value)
+}
+
+func TestUntypedToValueCoversEverySubtype(t *testing.T) {
+	assert.Equal(t, map[string]any{
+		"name":     "value",
+		"enabled":  true,
+		"count":    int32(3),
+		"big":      int64(9007199254740993),
+		"ratio":    float32(1.5),
+		"precise":  0.1,
+		"missing":  nil,
+		"tags":     []any{"a", "b"},
+		"mixed":    []any{"a", int32(1), nil, []any{false}},
+		"children": []any{map[string]any{"id": int64(1)}},
+		"nested":   map[string]any{"inner": map[string]any{}},
+	}, newTestUntypedObject().ToValue())
+	assert.Nil(t, NewUntypedNull().ToValue())
+	assert.Equal(t, "raw", NewUntypedNode("raw").ToValue())
+}
+
+// externalUntypedNode is an untyped node implemented outside of the package, it does not implement UntypedValueConverter.
+type externalUntypedNode struct {
+	name string
+}
+
+func (n *externalUntypedNode) GetIsUntypedNode() bool {
+	return true
+}
+
+func (n *externalUntypedNode) Serialize(writer SerializationWriter) error {
+	return writer.WriteStringValue("name", &n.name)
+}
+
+func (n *externalUntypedNode) GetFieldDeserializers() map[string]func(ParseNode) error {
+	return make(map[string]func(ParseNode) error)
+}
+
+func TestUntypedToValueConvertsExternalNodes(t *testing.T) {
+	var node UntypedNodeable = &externalUntypedNode{name: "external"}
+	_, isConverter := node.(UntypedValueConverter)
+	assert.False(t, isConverter)
+
+	value, err := UntypedToValue(NewUntypedArray([]UntypedNodeable{node, NewUntypedNull()}))
+	assert.Nil(t, err)
+	assert.Equal(t, []any{map[string]any{"name": "external"}, nil}, value)
+
+	native, err := ToNative(NewUntypedObject(map[string]UntypedNodeable{"external": node}))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"external": map[string]any{"name": "external"}}, native)
+}
+
+func TestUntypedFromValueRoundTrips(t *testing.T) {
+	expected := newTestUntypedObject()
+	node, err := UntypedFromValue(expected.ToValue())
+	assert.Nil(t, err)
+	assert.Equal(t, expected, node)
+}
+
+func TestUntypedFromValueConvertsGoValues(t *testing.T) {
+	name := "value"
+	var missing *string
+	node, err := UntypedFromValue(map[string]any{
+		"pointer": &name,
+		"nil":     missing,
+		"int":     42,
+		"numbers": []float64{1.5},
+		"number":  json.Number("12"),
+		"date":    *NewDateOnly(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, NewUntypedObject(map[string]UntypedNodeable{
+		"pointer": NewUntypedString("value"),
+		"nil":     NewUntypedNull(),
+		"int":     NewUntypedLong(42),
+		"numbers": NewUntypedArray([]UntypedNodeable{NewUntypedDouble(1.5)}),
+		"number":  NewUntypedLong(12),
+		"date":    NewUntypedString("2024-01-02"),
+	}), node)
+
+	_, err = UntypedFromValue(map[string]any{"channel": make(chan int)})
+	assert.EqualError(t, err, `could not convert property "channel": unsupported type chan int`)
+}
+
+func TestUntypedFromValueConvertsTypedNilStringers(t *testing.T) {
+	var date *DateOnly
+	var id *uuid.UUID
+	node, err := UntypedFromValue(date)
+	assert.Nil(t, err)
+	assert.Equal(t, NewUntypedNull(), node)
+
+	node, err = UntypedFromValue(map[string]any{"date": date, "ids": []*uuid.UUID{id}})
+	assert.Nil(t, err)
+	assert.Equal(t, NewUntypedObject(map[string]UntypedNodeable{
+		"date": NewUntypedNull(),
+		"ids":  NewUntypedArray([]UntypedNodeable{NewUntypedNull()}),
+	}), node)
+}
+
+func TestUntypedFromValueConvertsModels(t *testing.T) {
+	name := "parent"
+	node, err := UntypedFromValue(&nativeTestModel{name: &name, tags: []string{"a"}})
+	assert.Nil(t, err)
+	assert.Equal(t, NewUntypedObject(map[string]UntypedNodeable{
+		"name": NewUntypedString("parent"),
+		"tags": NewUntypedArray([]UntypedNodeable{NewUntypedString("a")}),
+	}), node)
+}
+
+func TestUntypedToParsableDecodesTypedModel(t *testing.T) {
+	node := NewUntypedObject(map[string]UntypedNodeable{
+		"name":     NewUntypedString("parent"),
+		"count":    NewUntypedLong(2),
+		"birthday": NewUntypedString("2024-01-02"),
+		"child":    NewUntypedObject(map[string]UntypedNodeable{"name": NewUntypedString("child")}),
+		"extra":    NewUntypedBoolean(true),
+	})
+	result, err := UntypedToParsable(node, createNativeTestModelFromDiscriminatorValue)
+	assert.Nil(t, err)
+	model := result.(*nativeTestModel)
+	assert.Equal(t, "parent", *model.name)
+	assert.Equal(t, int32(2), *model.count)
+	assert.Equal(t, "2024-01-02", model.birthday.String())
+	assert.Equal(t, "child", *model.child.name)
+	assert.Equal(t, map[string]any{"extra": true}, model.additionalData)
+}
@@ -10,6 +10,16 @@ func (un *UntypedNull) GetValue() any {
 	return nil
 }
 
+// Serialize returns an error as the value has no properties, write it with WriteUntypedValue instead of WriteObjectValue.
+func (un *UntypedNull) Serialize(writer SerializationWriter) error {
+	return errUntypedValueWithoutProperties(un)
+}
+
+// ToValue returns a nil value.
+func (un *UntypedNull) ToValue() any {
+	return nil
+}
+
 // NewUntypedString creates a new UntypedNull object.
 func NewUntypedNull() *UntypedNull {
 	m := &UntypedNull{}
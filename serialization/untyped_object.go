@@ -14,6 +14,23 @@ func (un *UntypedObject) GetValue() map[string]UntypedNodeable {
 	return nil
 }
 
+// Serialize writes the properties of the object to the current writer.
+func (un *UntypedObject) Serialize(writer SerializationWriter) error {
+	properties := un.GetValue()
+	for _, key := range sortedUntypedKeys(properties) {
+		if err := WriteUntypedValue(writer, key, properties[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToValue returns the properties of the object as a map of plain Go values, nested nodes failing to convert become nil, see UntypedToValue.
+func (un *UntypedObject) ToValue() any {
+	value, _ := UntypedToValue(un)
+	return value
+}
+
 // NewUntypedObject creates a new UntypedObject object.
 func NewUntypedObject(properties map[string]UntypedNodeable) *UntypedObject {
 	m := &UntypedObject{}
@@ -0,0 +1,410 @@
+package serialization
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUntypedPathNotFound is returned when a query segment does not match any value of the untyped tree.
+var ErrUntypedPathNotFound = errors.New("no value found")
+
+// UntypedQueryError describes the segment of a query that could not be resolved.
+type UntypedQueryError struct {
+	// Path is the full query.
+	Path string
+	// Segment is the segment of the query that failed.
+	Segment string
+	// Err is the reason of the failure.
+	Err error
+}
+
+// Error returns the error message.
+func (e *UntypedQueryError) Error() string {
+	return fmt.Sprintf("could not resolve segment %q of %q: %v", e.Segment, e.Path, e.Err)
+}
+
+// Unwrap returns the reason of the failure.
+func (e *UntypedQueryError) Unwrap() error {
+	return e.Err
+}
+
+// UntypedQueryResult is a value found by a query over an untyped tree.
+type UntypedQueryResult struct {
+	node UntypedNodeable
+	path string
+}
+
+// GetNode returns the untyped node found by the query.
+func (r *UntypedQueryResult) GetNode() UntypedNodeable {
+	return r.node
+}
+
+// GetPath returns the location of the value as a JSON Pointer.
+func (r *UntypedQueryResult) GetPath() string {
+	return r.path
+}
+
+// IsNull returns true if the value is null.
+func (r *UntypedQueryResult) IsNull() bool {
+	if isNilParsable(r.node) {
+		return true
+	}
+	_, ok := r.node.(*UntypedNull)
+	return ok
+}
+
+func (r *UntypedQueryResult) errUnexpectedType(target string) error {
+	return fmt.Errorf("value at %q is %s, not %s", r.path, untypedKind(r.node), target)
+}
+
+// AsString returns the value as a string.
+func (r *UntypedQueryResult) AsString() (string, error) {
+	if node, ok := r.node.(*UntypedString); ok && node.GetValue() != nil {
+		return *node.GetValue(), nil
+	}
+	return "", r.errUnexpectedType("a string")
+}
+
+// AsBool returns the value as a bool.
+func (r *UntypedQueryResult) AsBool() (bool, error) {
+	if node, ok := r.node.(*UntypedBoolean); ok && node.GetValue() != nil {
+		return *node.GetValue(), nil
+	}
+	return false, r.errUnexpectedType("a boolean")
+}
+
+// AsInt64 returns the value as an int64, only integer values can be converted.
+func (r *UntypedQueryResult) AsInt64() (int64, error) {
+	switch node := r.node.(type) {
+	case *UntypedInteger:
+		if node.GetValue() != nil {
+			return int64(*node.GetValue()), nil
+		}
+	case *UntypedLong:
+		if node.GetValue() != nil {
+			return *node.GetValue(), nil
+		}
+	}
+	return 0, r.errUnexpectedType("an integer")
+}
+
+// AsFloat64 returns the value as a float64, any numeric value can be converted.
+func (r *UntypedQueryResult) AsFloat64() (float64, error) {
+	switch node := r.node.(type) {
+	case *UntypedInteger:
+		if node.GetValue() != nil {
+			return float64(*node.GetValue()), nil
+		}
+	case *UntypedLong:
+		if node.GetValue() != nil {
+			return float64(*node.GetValue()), nil
+		}
+	case *UntypedFloat:
+		if node.GetValue() != nil {
+			return float64(*node.GetValue()), nil
+		}
+	case *UntypedDouble:
+		if node.GetValue() != nil {
+			return *node.GetValue(), nil
+		}
+	}
+	return 0, r.errUnexpectedType("a number")
+}
+
+// AsArray returns the items of the value when it is an array.
+func (r *UntypedQueryResult) AsArray() ([]UntypedNodeable, error) {
+	if node, ok := r.node.(*UntypedArray); ok && node.GetValue() != nil {
+		return node.GetValue(), nil
+	}
+	return nil, r.errUnexpectedType("an array")
+}
+
+// AsObject returns the properties of the value when it is an object.
+func (r *UntypedQueryResult) AsObject() (map[string]UntypedNodeable, error) {
+	if node, ok := r.node.(*UntypedObject); ok && node.GetValue() != nil {
+		return node.GetValue(), nil
+	}
+	return nil, r.errUnexpectedType("an object")
+}
+
+// untypedKind returns a description of the node type used in error messages.
+func untypedKind(node UntypedNodeable) string {
+	if isNilParsable(node) {
+		return "null"
+	}
+	switch node.(type) {
+	case *UntypedNull:
+		return "null"
+	case *UntypedString:
+		return "a string"
+	case *UntypedBoolean:
+		return "a boolean"
+	case *UntypedInteger, *UntypedLong, *UntypedFloat, *UntypedDouble:
+		return "a number"
+	case *UntypedArray:
+		return "an array"
+	case *UntypedObject:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", node)
+}
+
+// escapePointerToken escapes a reference token following RFC 6901.
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// unescapePointerToken unescapes a reference token following RFC 6901.
+func unescapePointerToken(token string) (string, error) {
+	for i := 0; i < len(token); i++ {
+		if token[i] == '~' && (i+1 >= len(token) || (token[i+1] != '0' && token[i+1] != '1')) {
+			return "", errors.New("invalid escape sequence")
+		}
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~"), nil
+}
+
+// parseArrayIndex parses an array index, negative values count from the end of the array when allowNegative is set.
+func parseArrayIndex(token string, length int, allowNegative bool) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || (!allowNegative && (index < 0 || strings.HasPrefix(token, "+"))) {
+		return 0, errors.New("invalid array index")
+	}
+	if !allowNegative && len(token) > 1 && token[0] == '0' {
+		return 0, errors.New("array index cannot have leading zeros")
+	}
+	if index < 0 {
+		index += length
+	}
+	if index < 0 || index >= length {
+		return 0, fmt.Errorf("index %s is out of range for an array of %d items: %w", token, length, ErrUntypedPathNotFound)
+	}
+	return index, nil
+}
+
+// QueryUntypedPointer returns the value of the untyped tree located by the JSON Pointer (RFC 6901).
+// The empty pointer returns the root of the tree.
+func QueryUntypedPointer(node UntypedNodeable, pointer string) (*UntypedQueryResult, error) {
+	result := &UntypedQueryResult{node: node, path: ""}
+	if pointer == "" {
+		return result, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, &UntypedQueryError{Path: pointer, Segment: pointer, Err: errors.New("a JSON Pointer must start with /")}
+	}
+	for _, segment := range strings.Split(pointer[1:], "/") {
+		token, err := unescapePointerToken(segment)
+		if err != nil {
+			return nil, &UntypedQueryError{Path: pointer, Segment: segment, Err: err}
+		}
+		switch current := result.node.(type) {
+		case *UntypedObject:
+			child, ok := current.GetValue()[token]
+			if !ok {
+				return nil, &UntypedQueryError{Path: pointer, Segment: segment, Err: ErrUntypedPathNotFound}
+			}
+			result = &UntypedQueryResult{node: child, path: result.path + "/" + segment}
+		case *UntypedArray:
+			items := current.GetValue()
+			if token == "-" {
+				return nil, &UntypedQueryError{Path: pointer, Segment: segment, Err: fmt.Errorf("the end of the array has no value: %w", ErrUntypedPathNotFound)}
+			}
+			index, err := parseArrayIndex(token, len(items), false)
+			if err != nil {
+				return nil, &UntypedQueryError{Path: pointer, Segment: segment, Err: err}
+			}
+			result = &UntypedQueryResult{node: items[index], path: result.path + "/" + segment}
+		default:
+			return nil, &UntypedQueryError{Path: pointer, Segment: segment, Err: fmt.Errorf("value at %q is %s and has no children", result.path, untypedKind(result.node))}
+		}
+	}
+	return result, nil
+}
+
+// untypedPathStep is a parsed segment of a JSONPath query.
+type untypedPathStep struct {
+	segment   string
+	name      string
+	index     string
+	wildcard  bool
+	recursive bool
+}
+
+// parseUntypedPath parses the supported JSONPath subset: $, .name, ['name'], [index], [*], .* and ..name.
+func parseUntypedPath(path string) ([]untypedPathStep, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, &UntypedQueryError{Path: path, Segment: path, Err: errors.New("a JSONPath must start with $")}
+	}
+	steps := make([]untypedPathStep, 0)
+	remaining := path[1:]
+	for remaining != "" {
+		start := remaining
+		step := untypedPathStep{}
+		switch {
+		case strings.HasPrefix(remaining, ".."):
+			step.recursive = true
+			remaining = remaining[2:]
+			if strings.HasPrefix(remaining, "[") {
+				break
+			}
+			name, rest := readPathName(remaining)
+			remaining = rest
+			if name == "" {
+				return nil, &UntypedQueryError{Path: path, Segment: start, Err: errors.New("missing property name after ..")}
+			}
+			step.wildcard = name == "*"
+			step.name = name
+			step.segment = ".." + name
+			steps = append(steps, step)
+			continue
+		case strings.HasPrefix(remaining, "."):
+			name, rest := readPathName(remaining[1:])
+			remaining = rest
+			if name == "" {
+				return nil, &UntypedQueryError{Path: path, Segment: start, Err: errors.New("missing property name after .")}
+			}
+			step.wildcard = name == "*"
+			step.name = name
+			step.segment = "." + name
+			steps = append(steps, step)
+			continue
+		case !strings.HasPrefix(remaining, "["):
+			return nil, &UntypedQueryError{Path: path, Segment: remaining, Err: errors.New("unexpected character, expected . or [")}
+		}
+		end := strings.Index(remaining, "]")
+		if end < 0 {
+			return nil, &UntypedQueryError{Path: path, Segment: remaining, Err: errors.New("missing closing ]")}
+		}
+		content := strings.TrimSpace(remaining[1:end])
+		step.segment = remaining[:end+1]
+		if step.recursive {
+			step.segment = ".." + step.segment
+		}
+		remaining = remaining[end+1:]
+		switch {
+		case content == "*":
+			step.wildcard = true
+		case len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0]:
+			step.name = content[1 : len(content)-1]
+		default:
+			if _, err := strconv.Atoi(content); err != nil {
+				return nil, &UntypedQueryError{Path: path, Segment: step.segment, Err: errors.New("expected a quoted property name, an index or *")}
+			}
+			step.index = content
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// readPathName reads a dot notation property name.
+func readPathName(path string) (string, string) {
+	end := strings.IndexAny(path, ".[")
+	if end < 0 {
+		return path, ""
+	}
+	return path[:end], path[end:]
+}
+
+// isDefinite returns true when the step selects at most one value.
+func (s untypedPathStep) isDefinite() bool {
+	return !s.wildcard && !s.recursive
+}
+
+// QueryUntyped returns the values of the untyped tree matching the JSONPath query.
+// The supported subset is $, .name, ['name'], [index] with negative indexes, [*], .* and the ..name recursive descent.
+// When every segment selects a single value, a segment that does not match returns an UntypedQueryError.
+func QueryUntyped(node UntypedNodeable, path string) ([]*UntypedQueryResult, error) {
+	steps, err := parseUntypedPath(path)
+	if err != nil {
+		return nil, err
+	}
+	results := []*UntypedQueryResult{{node: node, path: ""}}
+	definite := true
+	for _, step := range steps {
+		definite = definite && step.isDefinite()
+		next := make([]*UntypedQueryResult, 0)
+		for _, result := range results {
+			matches, err := step.apply(result)
+			if err != nil && definite {
+				return nil, &UntypedQueryError{Path: path, Segment: step.segment, Err: err}
+			}
+			next = append(next, matches...)
+		}
+		results = next
+	}
+	return results, nil
+}
+
+// QueryUntypedSingle returns the single value of the untyped tree matching the JSONPath query.
+func QueryUntypedSingle(node UntypedNodeable, path string) (*UntypedQueryResult, error) {
+	results, err := QueryUntyped(node, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != 1 {
+		return nil, &UntypedQueryError{Path: path, Segment: path, Err: fmt.Errorf("expected a single value, found %d", len(results))}
+	}
+	return results[0], nil
+}
+
+// apply returns the values selected by the step from the result.
+func (s untypedPathStep) apply(result *UntypedQueryResult) ([]*UntypedQueryResult, error) {
+	if s.recursive {
+		matches := make([]*UntypedQueryResult, 0)
+		for _, descendant := range untypedDescendants(result) {
+			selected, _ := untypedPathStep{name: s.name, index: s.index, wildcard: s.wildcard}.apply(descendant)
+			matches = append(matches, selected...)
+		}
+		return matches, nil
+	}
+	switch current := result.node.(type) {
+	case *UntypedObject:
+		properties := current.GetValue()
+		if s.wildcard {
+			matches := make([]*UntypedQueryResult, 0, len(properties))
+			for _, key := range sortedUntypedKeys(properties) {
+				matches = append(matches, &UntypedQueryResult{node: properties[key], path: result.path + "/" + escapePointerToken(key)})
+			}
+			return matches, nil
+		}
+		if s.index != "" {
+			return nil, fmt.Errorf("value at %q is an object and cannot be indexed", result.path)
+		}
+		child, ok := properties[s.name]
+		if !ok {
+			return nil, ErrUntypedPathNotFound
+		}
+		return []*UntypedQueryResult{{node: child, path: result.path + "/" + escapePointerToken(s.name)}}, nil
+	case *UntypedArray:
+		items := current.GetValue()
+		if s.wildcard {
+			matches := make([]*UntypedQueryResult, len(items))
+			for i, item := range items {
+				matches[i] = &UntypedQueryResult{node: item, path: result.path + "/" + strconv.Itoa(i)}
+			}
+			return matches, nil
+		}
+		if s.index == "" {
+			return nil, fmt.Errorf("value at %q is an array and has no property %q", result.path, s.name)
+		}
+		index, err := parseArrayIndex(s.index, len(items), true)
+		if err != nil {
+			return nil, err
+		}
+		return []*UntypedQueryResult{{node: items[index], path: result.path + "/" + strconv.Itoa(index)}}, nil
+	}
+	return nil, fmt.Errorf("value at %q is %s and has no children", result.path, untypedKind(result.node))
+}
+
+// untypedDescendants returns the result and all its descendants in document order.
+func untypedDescendants(result *UntypedQueryResult) []*UntypedQueryResult {
+	descendants := []*UntypedQueryResult{result}
+	children, _ := untypedPathStep{wildcard: true}.apply(result)
+	for _, child := range children {
+		descendants = append(descendants, untypedDescendants(child)...)
+	}
+	return descendants
+}
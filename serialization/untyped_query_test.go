@@ -0,0 +1,133 @@
+package serialization
+
+import (
+	"errors"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func newTestQueryTree() *UntypedObject {
+	return NewUntypedObject(map[string]UntypedNodeable{
+		"name": NewUntypedString("root"),
+		"a/b":  NewUntypedInteger(1),
+		"m~n":  NewUntypedLong(2),
+		"value": NewUntypedArray([]UntypedNodeable{
+			NewUntypedObject(map[string]UntypedNodeable{"id": NewUntypedString("1"), "score": NewUntypedDouble(1.5)}),
+			NewUntypedObject(map[string]UntypedNodeable{"id": NewUntypedString("2"), "score": NewUntypedInteger(3), "tags": NewUntypedArray([]UntypedNodeable{NewUntypedBoolean(true)})}),
+		}),
+		"empty": NewUntypedNull(),
+	})
+}
+
+func TestQueryUntypedPointer(t *testing.T) {
+	tree := newTestQueryTree()
+
+	result, err := QueryUntypedPointer(tree, "/value/1/id")
+	assert.Nil(t, err)
+	value, err := result.AsString()
+	assert.Nil(t, err)
+	assert.Equal(t, "2", value)
+
+	result, err = QueryUntypedPointer(tree, "/a~1b")
+	assert.Nil(t, err)
+	number, err := result.AsFloat64()
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1), number)
+
+	result, err = QueryUntypedPointer(tree, "/m~0n")
+	assert.Nil(t, err)
+	integer, err := result.AsInt64()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), integer)
+
+	result, err = QueryUntypedPointer(tree, "")
+	assert.Nil(t, err)
+	assert.Equal(t, tree, result.GetNode())
+
+	result, err = QueryUntypedPointer(tree, "/empty")
+	assert.Nil(t, err)
+	assert.True(t, result.IsNull())
+}
+
+func TestQueryUntypedPointerErrorsNameTheSegment(t *testing.T) {
+	tree := newTestQueryTree()
+
+	_, err := QueryUntypedPointer(tree, "/value/5/id")
+	assert.EqualError(t, err, `could not resolve segment "5" of "/value/5/id": index 5 is out of range for an array of 2 items: no value found`)
+	assert.True(t, errors.Is(err, ErrUntypedPathNotFound))
+
+	_, err = QueryUntypedPointer(tree, "/value/01")
+	assert.EqualError(t, err, `could not resolve segment "01" of "/value/01": array index cannot have leading zeros`)
+
+	_, err = QueryUntypedPointer(tree, "/missing")
+	var queryError *UntypedQueryError
+	assert.True(t, errors.As(err, &queryError))
+	assert.Equal(t, "missing", queryError.Segment)
+
+	_, err = QueryUntypedPointer(tree, "/name/first")
+	assert.EqualError(t, err, `could not resolve segment "first" of "/name/first": value at "/name" is a string and has no children`)
+
+	_, err = QueryUntypedPointer(tree, "name")
+	assert.NotNil(t, err)
+}
+
+func TestQueryUntypedAccessorsReportTypeMismatches(t *testing.T) {
+	result, err := QueryUntypedPointer(newTestQueryTree(), "/name")
+	assert.Nil(t, err)
+	_, err = result.AsFloat64()
+	assert.EqualError(t, err, `value at "/name" is a string, not a number`)
+	_, err = result.AsArray()
+	assert.EqualError(t, err, `value at "/name" is a string, not an array`)
+}
+
+func TestQueryUntyped(t *testing.T) {
+	tree := newTestQueryTree()
+
+	result, err := QueryUntypedSingle(tree, "$.value[-1]['id']")
+	assert.Nil(t, err)
+	value, err := result.AsString()
+	assert.Nil(t, err)
+	assert.Equal(t, "2", value)
+	assert.Equal(t, "/value/1/id", result.GetPath())
+
+	results, err := QueryUntyped(tree, "$.value[*].score")
+	assert.Nil(t, err)
+	scores := make([]float64, 0)
+	for _, result := range results {
+		score, err := result.AsFloat64()
+		assert.Nil(t, err)
+		scores = append(scores, score)
+	}
+	assert.Equal(t, []float64{1.5, 3}, scores)
+
+	results, err = QueryUntyped(tree, "$..id")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(results))
+
+	results, err = QueryUntyped(tree, "$.value[*].tags")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(results))
+	items, err := results[0].AsArray()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(items))
+}
+
+func TestQueryUntypedErrorsNameTheSegment(t *testing.T) {
+	tree := newTestQueryTree()
+
+	_, err := QueryUntyped(tree, "$.value[0].missing")
+	assert.EqualError(t, err, `could not resolve segment ".missing" of "$.value[0].missing": no value found`)
+
+	_, err = QueryUntyped(tree, "$.value.id")
+	assert.EqualError(t, err, `could not resolve segment ".id" of "$.value.id": value at "/value" is an array and has no property "id"`)
+
+	_, err = QueryUntyped(tree, "$.value[abc]")
+	assert.EqualError(t, err, `could not resolve segment "[abc]" of "$.value[abc]": expected a quoted property name, an index or *`)
+
+	_, err = QueryUntyped(tree, "value")
+	assert.NotNil(t, err)
+
+	_, err = QueryUntypedSingle(tree, "$.value[*]")
+	assert.EqualError(t, err, `could not resolve segment "$.value[*]" of "$.value[*]": expected a single value, found 2`)
+}
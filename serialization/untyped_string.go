@@ -14,6 +14,19 @@ func (un *UntypedString) GetValue() *string {
 	return nil
 }
 
+// Serialize returns an error as the value has no properties, write it with WriteUntypedValue instead of WriteObjectValue.
+func (un *UntypedString) Serialize(writer SerializationWriter) error {
+	return errUntypedValueWithoutProperties(un)
+}
+
+// ToValue returns the string value, or nil when the node has no value.
+func (un *UntypedString) ToValue() any {
+	if value := un.GetValue(); value != nil {
+		return *value
+	}
+	return nil
+}
+
 // NewUntypedString creates a new UntypedString object.
 func NewUntypedString(stringValue string) *UntypedString {
 	m := &UntypedString{}
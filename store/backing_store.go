@@ -1,5 +1,10 @@
 package store
 
+import (
+	"context"
+	"errors"
+)
+
 // BackingStore Stores model information in a different location than the object properties.
 // Implementations can provide dirty tracking capabilities, caching capabilities or integration with 3rd party stores.
 type BackingStore interface {
@@ -47,3 +52,105 @@ type BackingStore interface {
 	// since the initialization of the object when calling the Get and Enumerate method
 	SetReturnOnlyChangedValues(val bool)
 }
+
+// TransactionalBackingStore is a BackingStore whose state can be saved and restored, see RunInTransaction.
+type TransactionalBackingStore interface {
+	BackingStore
+
+	// Snapshot returns a copy of the values and change flags of the store and of the stores of its nested models.
+	Snapshot() *BackingStoreSnapshot
+
+	// Restore replaces the values and change flags with the ones of the snapshot.
+	// Will trigger subscriptions callbacks for the values that differ.
+	Restore(snapshot *BackingStoreSnapshot) error
+
+	// Begin starts a transaction, transactions can be nested.
+	Begin()
+
+	// Commit keeps the changes made since the start of the current transaction and ends it.
+	Commit() error
+
+	// Rollback restores the state the store had at the start of the current transaction and ends it.
+	// Will trigger subscriptions callbacks for the values that differ.
+	Rollback() error
+}
+
+// ObservableBackingStore is a BackingStore notifying the changes of selected keys, see the Watch function for the other stores.
+type ObservableBackingStore interface {
+	BackingStore
+
+	// SubscribeToKeys registers a listener to the changes of the values of the given keys, all the keys when none is given.
+	// returns a subscriptionId which can be used to reference the current subscription
+	SubscribeToKeys(callback BackingStoreSubscriber, keys ...string) string
+
+	// Watch returns a channel receiving the changes of the values of the given keys, all the keys when none is given.
+	// Changes are delivered asynchronously through a bounded buffer, the oldest changes are dropped when the receiver falls behind.
+	// The channel is closed once the context is done or the returned function is called, which must be called to release
+	// the subscription when the context is never done.
+	Watch(ctx context.Context, keys ...string) (<-chan Change, context.CancelFunc)
+}
+
+// Watch returns a channel receiving the changes of the values of the given keys of the store, all the keys when none is given.
+// It relies on ObservableBackingStore.Watch when the store implements it and on a subscription to all the changes otherwise.
+// The channel is closed once the context is done or the returned function is called, which must be called to release
+// the subscription when the context is never done.
+func Watch(ctx context.Context, store BackingStore, keys ...string) (<-chan Change, context.CancelFunc) {
+	if observable, ok := store.(ObservableBackingStore); ok {
+		return observable.Watch(ctx, keys...)
+	}
+	return watch(ctx, store, keys)
+}
+
+// Change describes a change of a value of a backing store.
+type Change struct {
+	// Key is the key of the value that changed.
+	Key string
+	// OldValue is the previous value.
+	OldValue interface{}
+	// NewValue is the newly assigned value.
+	NewValue interface{}
+}
+
+// BackingStoreSnapshot holds a copy of the values and change flags of a backing store.
+type BackingStoreSnapshot struct {
+	// Values holds the values of the store, collections are copied so changes made in place are undone.
+	Values map[string]interface{}
+	// ChangedValues holds the change flags of the values.
+	ChangedValues map[string]bool
+	// Nested holds the snapshots of the stores of the nested models.
+	Nested []NestedBackingStoreSnapshot
+}
+
+// NestedBackingStoreSnapshot holds the snapshot of the store of a nested model.
+type NestedBackingStoreSnapshot struct {
+	// Store is the store of the nested model.
+	Store TransactionalBackingStore
+	// Snapshot is the snapshot of the store.
+	Snapshot *BackingStoreSnapshot
+}
+
+// ErrNotTransactional is returned when running a transaction on a store that does not implement TransactionalBackingStore.
+var ErrNotTransactional = errors.New("the backing store does not support transactions")
+
+// RunInTransaction runs the action in a transaction of the store, which is committed when the action succeeds and rolled back when it returns an error or panics.
+// It returns ErrNotTransactional without running the action when the store does not implement TransactionalBackingStore.
+func RunInTransaction(backingStore BackingStore, action func() error) (err error) {
+	store, ok := backingStore.(TransactionalBackingStore)
+	if !ok {
+		return ErrNotTransactional
+	}
+	store.Begin()
+	committed := false
+	defer func() {
+		if !committed {
+			if rollbackErr := store.Rollback(); rollbackErr != nil && err == nil {
+				err = rollbackErr
+			}
+		}
+	}()
+	if err = action(); err != nil {
+		return err
+	}
+	committed = true
+	return store.Commit()
+}
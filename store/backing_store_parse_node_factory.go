@@ -1,6 +1,10 @@
 package store
 
-import "github.com/microsoft/kiota-abstractions-go/serialization"
+import (
+	"io"
+
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+)
 
 // BackingStoreParseNodeFactory Backing Store implementation for serialization.ParseNodeFactory
 type BackingStoreParseNodeFactory struct {
@@ -9,6 +13,12 @@ type BackingStoreParseNodeFactory struct {
 
 // NewBackingStoreParseNodeFactory Initializes a new instance of BackingStoreParseNodeFactory
 func NewBackingStoreParseNodeFactory(factory serialization.ParseNodeFactory) *BackingStoreParseNodeFactory {
+	return NewBackingStoreParseNodeFactoryWithIdentityMap(factory, nil)
+}
+
+// NewBackingStoreParseNodeFactoryWithIdentityMap Initializes a new instance of BackingStoreParseNodeFactory resolving the deserialized models with the identity map,
+// the values of known entities are merged into the backing store of their known instance. A nil identity map disables the resolution.
+func NewBackingStoreParseNodeFactoryWithIdentityMap(factory serialization.ParseNodeFactory, identityMap *IdentityMap) *BackingStoreParseNodeFactory {
 	proxyFactory := serialization.NewParseNodeProxyFactory(factory, func(parsable serialization.Parsable) error {
 		if backedModel, ok := parsable.(BackedModel); ok && backedModel.GetBackingStore() != nil {
 			backedModel.GetBackingStore().SetInitializationCompleted(false)
@@ -17,9 +27,19 @@ func NewBackingStoreParseNodeFactory(factory serialization.ParseNodeFactory) *Ba
 	}, func(parsable serialization.Parsable) error {
 		if backedModel, ok := parsable.(BackedModel); ok && backedModel.GetBackingStore() != nil {
 			backedModel.GetBackingStore().SetInitializationCompleted(true)
+			if identityMap != nil {
+				if _, err := identityMap.Resolve(backedModel); err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	})
 
 	return &BackingStoreParseNodeFactory{proxyFactory}
 }
+
+// GetRootParseNodeFromReader returns a new ParseNode instance that is the root of the content read from the reader.
+func (b *BackingStoreParseNodeFactory) GetRootParseNodeFromReader(contentType string, content io.Reader) (serialization.ParseNode, error) {
+	return serialization.NewRootParseNodeFromReader(b.ParseNodeFactory, contentType, content)
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+type nativeJsonParseNodeFactory struct {
+}
+
+func (f *nativeJsonParseNodeFactory) GetValidContentType() (string, error) {
+	return "application/json", nil
+}
+
+func (f *nativeJsonParseNodeFactory) GetRootParseNode(contentType string, content []byte) (serialization.ParseNode, error) {
+	var value any
+	if err := json.Unmarshal(content, &value); err != nil {
+		return nil, err
+	}
+	return serialization.NewNativeParseNode(value), nil
+}
+
+func TestBackingStoreParseNodeFactoryCompletesInitializationOfNativeNodes(t *testing.T) {
+	factory := NewBackingStoreParseNodeFactory(&nativeJsonParseNodeFactory{})
+	node, err := factory.GetRootParseNode("application/json", []byte(`{"id":"1","name":"parent","items":[{"id":"2"}]}`))
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(createTestEntityFromDiscriminatorValue)
+	assert.Nil(t, err)
+
+	entity := result.(*testEntity)
+	assert.Equal(t, "parent", *entity.GetName())
+	assert.Equal(t, "2", *entity.GetItems()[0].GetId())
+	assert.True(t, entity.GetBackingStore().GetInitializationCompleted())
+	assert.True(t, entity.GetItems()[0].GetBackingStore().GetInitializationCompleted())
+
+	entity.GetBackingStore().SetReturnOnlyChangedValues(true)
+	assert.Empty(t, entity.GetBackingStore().Enumerate())
+	name := "changed"
+	entity.SetName(&name)
+	assert.Equal(t, map[string]interface{}{"name": &name}, entity.GetBackingStore().Enumerate())
+}
@@ -1,6 +1,8 @@
 package store
 
 import (
+	"io"
+
 	"github.com/microsoft/kiota-abstractions-go/serialization"
 )
 
@@ -17,6 +19,10 @@ func (b *BackingStoreSerializationWriterProxyFactory) GetSerializationWriter(con
 	return b.factory.GetSerializationWriter(contentType)
 }
 
+func (b *BackingStoreSerializationWriterProxyFactory) GetStreamSerializationWriter(contentType string, w io.Writer) (serialization.SerializationWriter, error) {
+	return serialization.NewStreamSerializationWriter(b.factory, contentType, w)
+}
+
 // NewBackingStoreSerializationWriterProxyFactory Initializes a new instance of BackingStoreSerializationWriterProxyFactory
 func NewBackingStoreSerializationWriterProxyFactory(factory serialization.SerializationWriterFactory) *BackingStoreSerializationWriterProxyFactory {
 	proxyFactory := serialization.NewSerializationWriterProxyFactory(factory, func(parsable serialization.Parsable) error {
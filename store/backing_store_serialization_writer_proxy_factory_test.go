@@ -0,0 +1,51 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+type nativeSerializationWriterFactory struct {
+}
+
+func (f *nativeSerializationWriterFactory) GetValidContentType() (string, error) {
+	return "application/json", nil
+}
+
+func (f *nativeSerializationWriterFactory) GetSerializationWriter(contentType string) (serialization.SerializationWriter, error) {
+	return serialization.NewNativeSerializationWriter(), nil
+}
+
+func serializeChanges(t *testing.T, model serialization.Parsable) any {
+	factory := NewBackingStoreSerializationWriterProxyFactory(&nativeSerializationWriterFactory{})
+	writer, err := factory.GetSerializationWriter("application/json")
+	assert.Nil(t, err)
+	assert.Nil(t, writer.WriteObjectValue("", model))
+	return writer.(*serialization.NativeSerializationWriter).GetValue()
+}
+
+func TestBackingStoreSerializationWriterWritesNestedChanges(t *testing.T) {
+	factory := NewBackingStoreParseNodeFactory(&nativeJsonParseNodeFactory{})
+	node, err := factory.GetRootParseNode("application/json", []byte(`{"id":"1","name":"parent","manager":{"id":"2","name":"boss"},"items":[{"id":"3","name":"a"},{"id":"4","name":"b"}]}`))
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(createTestEntityFromDiscriminatorValue)
+	assert.Nil(t, err)
+	entity := result.(*testEntity)
+
+	assert.Equal(t, map[string]any{}, serializeChanges(t, entity))
+
+	managerName := "new boss"
+	entity.GetManager().SetName(&managerName)
+	itemName := "changed"
+	entity.GetItems()[1].SetName(&itemName)
+
+	assert.Equal(t, map[string]any{
+		"manager": map[string]any{"name": "new boss"},
+		"items": []any{
+			map[string]any{"id": "3", "name": "a"},
+			map[string]any{"id": "4", "name": "changed"},
+		},
+	}, serializeChanges(t, entity))
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrBackingStoreStateNotFound is returned by storages when no state was saved under an id.
+var ErrBackingStoreStateNotFound = errors.New("no backing store state was saved under this id")
+
+// BackingStoreStorage persists the state of backing stores under an id.
+type BackingStoreStorage interface {
+	// Load returns the state saved under the id, ErrBackingStoreStateNotFound when none was saved.
+	Load(id string) ([]byte, error)
+	// Save saves the state under the id, replacing the state saved previously.
+	Save(id string, state []byte) error
+	// Delete removes the state saved under the id, it does not fail when none was saved.
+	Delete(id string) error
+}
+
+// DirectoryBackingStoreStorage is a BackingStoreStorage saving each state in a file of a local directory.
+type DirectoryBackingStoreStorage struct {
+	path string
+}
+
+// NewDirectoryBackingStoreStorage returns a new DirectoryBackingStoreStorage saving the states in the directory, which is created when missing.
+func NewDirectoryBackingStoreStorage(path string) (*DirectoryBackingStoreStorage, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.New("path cannot be an empty string")
+	}
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		return nil, err
+	}
+	return &DirectoryBackingStoreStorage{
+		path: path,
+	}, nil
+}
+
+func (d *DirectoryBackingStoreStorage) fileName(id string) (string, error) {
+	if strings.TrimSpace(id) == "" {
+		return "", errors.New("id cannot be an empty string")
+	}
+	return filepath.Join(d.path, url.PathEscape(id)+".json"), nil
+}
+
+// Load returns the state saved under the id, ErrBackingStoreStateNotFound when none was saved.
+func (d *DirectoryBackingStoreStorage) Load(id string) ([]byte, error) {
+	fileName, err := d.fileName(id)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBackingStoreStateNotFound
+	}
+	return content, err
+}
+
+// Save saves the state under the id, the file is replaced atomically so a crash never leaves a partial state.
+func (d *DirectoryBackingStoreStorage) Save(id string, state []byte) error {
+	fileName, err := d.fileName(id)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(d.path, ".state-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(state); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), fileName)
+}
+
+// Delete removes the state saved under the id, it does not fail when none was saved.
+func (d *DirectoryBackingStoreStorage) Delete(id string) error {
+	fileName, err := d.fileName(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fileName); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
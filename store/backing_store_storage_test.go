@@ -0,0 +1,38 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectoryBackingStoreStorage(t *testing.T) {
+	directory := filepath.Join(t.TempDir(), "states")
+	storage, err := NewDirectoryBackingStoreStorage(directory)
+	assert.Nil(t, err)
+
+	_, err = storage.Load("users/1")
+	assert.Equal(t, ErrBackingStoreStateNotFound, err)
+
+	assert.Nil(t, storage.Save("users/1", []byte(`{"a":1}`)))
+	assert.Nil(t, storage.Save("users/1", []byte(`{"a":2}`)))
+	content, err := storage.Load("users/1")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":2}`, string(content))
+
+	files, err := os.ReadDir(directory)
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, "users%2F1.json", files[0].Name())
+
+	assert.Nil(t, storage.Delete("users/1"))
+	assert.Nil(t, storage.Delete("users/1"))
+	_, err = storage.Load("users/1")
+	assert.Equal(t, ErrBackingStoreStateNotFound, err)
+
+	assert.NotNil(t, storage.Save(" ", nil))
+	_, err = NewDirectoryBackingStoreStorage("")
+	assert.NotNil(t, err)
+}
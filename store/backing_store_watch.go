@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// watchBufferSize is the number of changes a watch channel holds before dropping the oldest ones.
+const watchBufferSize = 64
+
+// filterKeys returns a subscriber invoking the callback for the changes of the keys only, all the keys when none is given.
+func filterKeys(callback BackingStoreSubscriber, keys []string) BackingStoreSubscriber {
+	if len(keys) == 0 {
+		return callback
+	}
+	filter := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		filter[strings.TrimSpace(k)] = true
+	}
+	return func(key string, oldVal interface{}, newVal interface{}) {
+		if filter[key] {
+			callback(key, oldVal, newVal)
+		}
+	}
+}
+
+// changeWatcher delivers the changes of a store to a buffered channel without blocking the store.
+type changeWatcher struct {
+	lock    sync.Mutex
+	changes chan Change
+	closed  bool
+}
+
+// watch subscribes to the changes of the keys of the store until the context is done or the returned function is called.
+func watch(ctx context.Context, store BackingStore, keys []string) (<-chan Change, context.CancelFunc) {
+	watcher := &changeWatcher{
+		changes: make(chan Change, watchBufferSize),
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	if ctx.Err() != nil {
+		watcher.close()
+		return watcher.changes, cancel
+	}
+	id := store.Subscribe(filterKeys(func(key string, oldVal interface{}, newVal interface{}) {
+		watcher.send(Change{
+			Key:      key,
+			OldValue: oldVal,
+			NewValue: newVal,
+		})
+	}, keys))
+	go func() {
+		<-ctx.Done()
+		_ = store.Unsubscribe(id)
+		watcher.close()
+	}()
+	return watcher.changes, cancel
+}
+
+// send adds the change to the buffer, dropping the oldest change when the buffer is full.
+func (w *changeWatcher) send(change Change) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.closed {
+		return
+	}
+	for {
+		select {
+		case w.changes <- change:
+			return
+		default:
+		}
+		select {
+		case <-w.changes:
+		default:
+		}
+	}
+}
+
+// close closes the channel, the changes sent afterwards are ignored.
+func (w *changeWatcher) close() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if !w.closed {
+		w.closed = true
+		close(w.changes)
+	}
+}
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryBackingStoreIsObservable(t *testing.T) {
+	assert.Implements(t, (*ObservableBackingStore)(nil), NewInMemoryBackingStore())
+	assert.Implements(t, (*ObservableBackingStore)(nil), NewPersistentBackingStore(nil, "me"))
+}
+
+func TestSubscribeToKeys(t *testing.T) {
+	memoryStore := newInMemoryBackingStore()
+	keys := make([]string, 0)
+	id := memoryStore.SubscribeToKeys(func(key string, oldVal interface{}, newVal interface{}) {
+		keys = append(keys, key)
+	}, "name", " id ")
+
+	_ = memoryStore.Set("name", "first")
+	_ = memoryStore.Set("phoneNumbers", []string{"+1234"})
+	_ = memoryStore.Set("id", "1")
+	assert.Equal(t, []string{"name", "id"}, keys)
+
+	assert.Nil(t, memoryStore.Unsubscribe(id))
+	_ = memoryStore.Set("name", "second")
+	assert.Equal(t, []string{"name", "id"}, keys)
+}
+
+func TestWatchDeliversChangesOfTheKeys(t *testing.T) {
+	memoryStore := NewInMemoryBackingStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, stop := Watch(ctx, memoryStore, "name")
+	defer stop()
+
+	_ = memoryStore.Set("id", "1")
+	_ = memoryStore.Set("name", "first")
+	_ = memoryStore.Set("name", "second")
+
+	assert.Equal(t, Change{Key: "name", OldValue: nil, NewValue: "first"}, <-changes)
+	assert.Equal(t, Change{Key: "name", OldValue: "first", NewValue: "second"}, <-changes)
+
+	cancel()
+	_, open := <-changes
+	assert.False(t, open)
+	// changes made after the cancellation do not panic
+	_ = memoryStore.Set("name", "third")
+}
+
+func TestWatchDeliversChangesOfNestedModels(t *testing.T) {
+	manager := newInitializedTestEntity("2", nil)
+	parent := newInitializedTestEntity("1", func(entity *testEntity) {
+		entity.SetManager(manager)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, stop := Watch(ctx, parent.GetBackingStore())
+	defer stop()
+
+	name := "boss"
+	manager.SetName(&name)
+	change := <-changes
+	assert.Equal(t, "manager", change.Key)
+}
+
+func TestWatchDropsTheOldestChanges(t *testing.T) {
+	memoryStore := NewInMemoryBackingStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, stop := Watch(ctx, memoryStore)
+	defer stop()
+
+	for i := 0; i < watchBufferSize+10; i++ {
+		_ = memoryStore.Set("count", i)
+	}
+	assert.Len(t, changes, watchBufferSize)
+	assert.Equal(t, 10, (<-changes).NewValue)
+}
+
+func TestWatchWithDoneContext(t *testing.T) {
+	memoryStore := NewInMemoryBackingStore()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	changes, stop := Watch(ctx, memoryStore)
+	defer stop()
+	_ = memoryStore.Set("name", "first")
+	_, open := <-changes
+	assert.False(t, open)
+}
+
+func TestWatchStopsWithoutCancellableContext(t *testing.T) {
+	memoryStore := newInMemoryBackingStore()
+	changes, stop := memoryStore.Watch(context.Background())
+	_ = memoryStore.Set("name", "first")
+	assert.Equal(t, "first", (<-changes).NewValue)
+
+	stop()
+	_, open := <-changes
+	assert.False(t, open)
+	assert.Eventually(t, func() bool {
+		memoryStore.lock.RLock()
+		defer memoryStore.lock.RUnlock()
+		return len(memoryStore.subscribers) == 0
+	}, time.Second, time.Millisecond)
+}
+
+// plainBackingStore hides the ObservableBackingStore methods of the store it wraps.
+type plainBackingStore struct {
+	BackingStore
+}
+
+func TestWatchStoresThatAreNotObservable(t *testing.T) {
+	store := &plainBackingStore{NewInMemoryBackingStore()}
+	_, isObservable := BackingStore(store).(ObservableBackingStore)
+	assert.False(t, isObservable)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, stop := Watch(ctx, store, "name")
+	defer stop()
+	_ = store.Set("id", "1")
+	_ = store.Set("name", "first")
+	assert.Equal(t, Change{Key: "name", OldValue: nil, NewValue: "first"}, <-changes)
+
+	cancel()
+	_, open := <-changes
+	assert.False(t, open)
+}
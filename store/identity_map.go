@@ -0,0 +1,231 @@
+package store
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"weak"
+)
+
+// IdentityKeyResolver returns the key identifying the entity represented by the model, false when the model cannot be identified.
+type IdentityKeyResolver func(model BackedModel) (string, bool)
+
+// IdentityMap resolves the backed models representing the same entity to a single instance, safe for concurrent use.
+// When another instance of a known entity is resolved, its values are merged into the backing store of the known instance,
+// except for the values changed locally, and both stores are linked so the changes made to one of them are applied to the other.
+// Only the models whose backing store is an InMemoryBackingStore, or is built on one, are merged and linked.
+// The map holds the known instances until they are removed, the linked instances are held weakly and unlinked once collected.
+type IdentityMap struct {
+	lock     sync.Mutex
+	resolver IdentityKeyResolver
+	entries  map[string]*identityMapEntry
+}
+
+// identityMapEntry holds the known instance of an entity and the stores linked to its store.
+type identityMapEntry struct {
+	lock  sync.Mutex
+	model BackedModel
+	// links holds the ids of the subscriptions linking the stores to the store of the known instance
+	links    map[weak.Pointer[InMemoryBackingStore]]string
+	lastLink uint64
+}
+
+// NewIdentityMap returns a new IdentityMap identifying the models by their type and the value held by the key in their backing store, e.g. id.
+// The keys of the known instances are formatted as type/value, e.g. *models.User/1.
+func NewIdentityMap(key string) *IdentityMap {
+	key = strings.TrimSpace(key)
+	return NewIdentityMapWithResolver(func(model BackedModel) (string, bool) {
+		store := backingStoreOf(model)
+		if store == nil || key == "" {
+			return "", false
+		}
+		value, err := store.Get(key)
+		if err != nil {
+			return "", false
+		}
+		id, ok := identityKeyOf(value)
+		if !ok {
+			return "", false
+		}
+		return reflect.TypeOf(model).String() + "/" + id, true
+	})
+}
+
+// NewIdentityMapWithResolver returns a new IdentityMap identifying the models with the resolver.
+func NewIdentityMapWithResolver(resolver IdentityKeyResolver) *IdentityMap {
+	return &IdentityMap{
+		resolver: resolver,
+		entries:  make(map[string]*identityMapEntry),
+	}
+}
+
+// identityKeyOf returns the text representation of the value, false when the value is nil or empty.
+func identityKeyOf(value interface{}) (string, bool) {
+	for value != nil {
+		reflected := reflect.ValueOf(value)
+		if reflected.Kind() != reflect.Pointer {
+			break
+		}
+		if reflected.IsNil() {
+			return "", false
+		}
+		value = reflected.Elem().Interface()
+	}
+	if value == nil {
+		return "", false
+	}
+	key := fmt.Sprint(value)
+	return key, key != ""
+}
+
+// Get returns the known instance of the entity identified by the key.
+func (m *IdentityMap) Get(key string) (BackedModel, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	return entry.model, true
+}
+
+// Resolve returns the known instance of the entity represented by the model, the model becomes the known instance when the entity is unknown.
+// The values of the model are merged into the known instance and the model is linked to it.
+func (m *IdentityMap) Resolve(model BackedModel) (BackedModel, error) {
+	if model == nil || backingStoreOf(model) == nil || m.resolver == nil {
+		return model, nil
+	}
+	key, ok := m.resolver(model)
+	if !ok {
+		return model, nil
+	}
+
+	m.lock.Lock()
+	entry, ok := m.entries[key]
+	if !ok {
+		m.entries[key] = &identityMapEntry{
+			model: model,
+			links: make(map[weak.Pointer[InMemoryBackingStore]]string),
+		}
+		m.lock.Unlock()
+		return model, nil
+	}
+	m.lock.Unlock()
+
+	if err := entry.merge(model); err != nil {
+		return nil, err
+	}
+	return entry.model, nil
+}
+
+// Remove forgets the entity identified by the key, the stores linked to its known instance are no longer updated.
+func (m *IdentityMap) Remove(key string) {
+	m.lock.Lock()
+	entry, ok := m.entries[key]
+	delete(m.entries, key)
+	m.lock.Unlock()
+
+	if ok {
+		entry.unlink()
+	}
+}
+
+// Clear forgets all the entities.
+func (m *IdentityMap) Clear() {
+	m.lock.Lock()
+	entries := m.entries
+	m.entries = make(map[string]*identityMapEntry)
+	m.lock.Unlock()
+
+	for _, entry := range entries {
+		entry.unlink()
+	}
+}
+
+// merge merges the values of the model into the store of the known instance and links the store of the model to it.
+func (e *identityMapEntry) merge(model BackedModel) error {
+	known, ok := asInMemoryBackingStore(backingStoreOf(e.model))
+	if !ok {
+		return nil
+	}
+	store, ok := asInMemoryBackingStore(backingStoreOf(model))
+	if !ok || known == store {
+		return nil
+	}
+	pointer := weak.Make(store)
+	e.lock.Lock()
+	_, linked := e.links[pointer]
+	e.lock.Unlock()
+	if linked {
+		return nil
+	}
+
+	// values changed locally are kept, the other ones are updated without being marked as changed
+	changed := known.Snapshot().ChangedValues
+	for k, v := range store.Snapshot().Values {
+		if !changed[k] {
+			known.setWithChangedFlag(k, v, false)
+		}
+	}
+	if err := store.Restore(known.Snapshot()); err != nil {
+		return err
+	}
+
+	e.lock.Lock()
+	e.lastLink++
+	id := fmt.Sprintf("%p/%d", e, e.lastLink)
+	e.links[pointer] = id
+	e.lock.Unlock()
+	_ = known.SubscribeWithId(func(key string, _ interface{}, _ interface{}) {
+		if target := pointer.Value(); target != nil {
+			propagate(known, target, key)
+		}
+	}, id)
+	_ = store.SubscribeWithId(func(key string, _ interface{}, _ interface{}) {
+		propagate(store, known, key)
+	}, id)
+	runtime.AddCleanup(store, e.dropLink, pointer)
+	return nil
+}
+
+// propagate applies the value held by the key in the source store to the target store, with its change flag.
+// The current value is applied rather than the notified one so concurrent changes converge,
+// the propagation stops at the stores already holding the value.
+func propagate(source *InMemoryBackingStore, target *InMemoryBackingStore, key string) {
+	if value, changed, ok := source.valueOf(key); ok {
+		target.setWithChangedFlag(key, copyValue(value), changed)
+	}
+}
+
+// dropLink removes the link to a store once it is collected.
+func (e *identityMapEntry) dropLink(pointer weak.Pointer[InMemoryBackingStore]) {
+	e.lock.Lock()
+	id, ok := e.links[pointer]
+	delete(e.links, pointer)
+	e.lock.Unlock()
+
+	if known, isInMemory := asInMemoryBackingStore(backingStoreOf(e.model)); ok && isInMemory {
+		_ = known.Unsubscribe(id)
+	}
+}
+
+// unlink removes the subscriptions linking the stores to the store of the known instance.
+func (e *identityMapEntry) unlink() {
+	e.lock.Lock()
+	links := e.links
+	e.links = make(map[weak.Pointer[InMemoryBackingStore]]string)
+	e.lock.Unlock()
+
+	known, ok := asInMemoryBackingStore(backingStoreOf(e.model))
+	if !ok {
+		return
+	}
+	for pointer, id := range links {
+		_ = known.Unsubscribe(id)
+		if store := pointer.Value(); store != nil {
+			_ = store.Unsubscribe(id)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package store
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseWithIdentityMap(t *testing.T, identityMap *IdentityMap, content string) *testEntity {
+	node, err := NewBackingStoreParseNodeFactoryWithIdentityMap(&nativeJsonParseNodeFactory{}, identityMap).GetRootParseNode("application/json", []byte(content))
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(createTestEntityFromDiscriminatorValue)
+	assert.Nil(t, err)
+	return result.(*testEntity)
+}
+
+func TestIdentityMapMergesKnownEntities(t *testing.T) {
+	identityMap := NewIdentityMap("id")
+	me := parseWithIdentityMap(t, identityMap, `{"id":"1","name":"me","phoneNumbers":["+1234"]}`)
+	members := parseWithIdentityMap(t, identityMap, `{"id":"0","items":[{"id":"1","manager":{"id":"2"}},{"id":"3"}]}`)
+	member := members.GetItems()[0]
+
+	known, ok := identityMap.Get("*store.testEntity/1")
+	assert.True(t, ok)
+	assert.Same(t, me, known)
+	_, ok = identityMap.Get("*store.testEntity/3")
+	assert.True(t, ok)
+
+	// the values of both responses are merged without being marked as changed
+	assert.Equal(t, "me", *member.GetName())
+	assert.Equal(t, "2", *me.GetManager().GetId())
+	me.GetBackingStore().SetReturnOnlyChangedValues(true)
+	assert.Empty(t, me.GetBackingStore().Enumerate())
+	me.GetBackingStore().SetReturnOnlyChangedValues(false)
+
+	// changes made to one instance are applied to the other one
+	name := "changed"
+	member.SetName(&name)
+	assert.Equal(t, "changed", *me.GetName())
+	me.SetPhoneNumbers([]string{"+2345"})
+	assert.Equal(t, []string{"+2345"}, member.GetPhoneNumbers())
+
+	patch, err := GetMergePatch(me)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"name": "changed", "phoneNumbers": []any{"+2345"}}, patch)
+	// the member is an item of a changed collection, it is written entirely
+	patch, err = GetMergePatch(members)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"items": []any{
+		map[string]any{"id": "1", "name": "changed", "phoneNumbers": []any{"+2345"}, "manager": map[string]any{"id": "2"}},
+		map[string]any{"id": "3"},
+	}}, patch)
+}
+
+func TestIdentityMapKeepsLocalChanges(t *testing.T) {
+	identityMap := NewIdentityMap("id")
+	me := parseWithIdentityMap(t, identityMap, `{"id":"1","name":"me","phoneNumbers":["+1234"]}`)
+	name := "local"
+	me.SetName(&name)
+
+	again := parseWithIdentityMap(t, identityMap, `{"id":"1","name":"server","phoneNumbers":["+2345"]}`)
+	assert.Equal(t, "local", *me.GetName())
+	assert.Equal(t, "local", *again.GetName())
+	assert.Equal(t, []string{"+2345"}, me.GetPhoneNumbers())
+
+	me.GetBackingStore().SetReturnOnlyChangedValues(true)
+	again.GetBackingStore().SetReturnOnlyChangedValues(true)
+	assert.Equal(t, []string{"name"}, keysOf(me.GetBackingStore().Enumerate()))
+	assert.Equal(t, []string{"name"}, keysOf(again.GetBackingStore().Enumerate()))
+}
+
+func TestIdentityMapIgnoresModelsWithoutKey(t *testing.T) {
+	identityMap := NewIdentityMap("id")
+	first := parseWithIdentityMap(t, identityMap, `{"name":"first"}`)
+	second := parseWithIdentityMap(t, identityMap, `{"name":"second"}`)
+	assert.Equal(t, "first", *first.GetName())
+	assert.Equal(t, "second", *second.GetName())
+
+	resolved, err := identityMap.Resolve(&nonBackedModel{})
+	assert.Nil(t, err)
+	assert.Nil(t, resolved.GetBackingStore())
+}
+
+func TestIdentityMapRemoveUnlinksTheInstances(t *testing.T) {
+	identityMap := NewIdentityMapWithResolver(func(model BackedModel) (string, bool) {
+		if entity, ok := model.(*testEntity); ok && entity.GetId() != nil {
+			return "entity/" + *entity.GetId(), true
+		}
+		return "", false
+	})
+	first := parseWithIdentityMap(t, identityMap, `{"id":"1","name":"first"}`)
+	second := parseWithIdentityMap(t, identityMap, `{"id":"1"}`)
+	assert.Equal(t, "first", *second.GetName())
+
+	identityMap.Remove("entity/1")
+	_, ok := identityMap.Get("entity/1")
+	assert.False(t, ok)
+	name := "changed"
+	second.SetName(&name)
+	assert.Equal(t, "first", *first.GetName())
+
+	third := parseWithIdentityMap(t, identityMap, `{"id":"1","name":"third"}`)
+	fourth := parseWithIdentityMap(t, identityMap, `{"id":"1"}`)
+	assert.Equal(t, "third", *fourth.GetName())
+	identityMap.Clear()
+	fourth.SetName(&name)
+	assert.Equal(t, "third", *third.GetName())
+}
+
+func TestIdentityMapKeysIncludeTheModelType(t *testing.T) {
+	identityMap := NewIdentityMap("id")
+	entity := parseWithIdentityMap(t, identityMap, `{"id":"1","name":"entity"}`)
+	id := "1"
+	other := &otherEntity{backingStore: NewInMemoryBackingStore()}
+	assert.Nil(t, other.GetBackingStore().Set("id", &id))
+	resolved, err := identityMap.Resolve(other)
+	assert.Nil(t, err)
+	assert.Same(t, other, resolved)
+
+	known, ok := identityMap.Get("*store.testEntity/1")
+	assert.True(t, ok)
+	assert.Same(t, entity, known)
+	known, ok = identityMap.Get("*store.otherEntity/1")
+	assert.True(t, ok)
+	assert.Same(t, other, known)
+}
+
+func TestIdentityMapDoesNotHoldTheLinkedInstances(t *testing.T) {
+	identityMap := NewIdentityMap("id")
+	me := parseWithIdentityMap(t, identityMap, `{"id":"1","manager":{"id":"2"}}`)
+	for range 10 {
+		parseWithIdentityMap(t, identityMap, `{"id":"1","name":"again"}`)
+	}
+	assert.Equal(t, "again", *me.GetName())
+
+	known := me.GetBackingStore().(*InMemoryBackingStore)
+	manager := me.GetManager().GetBackingStore().(*InMemoryBackingStore)
+	entry := identityMap.entries["*store.testEntity/1"]
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+		// the subscriptions of the collected parents are removed on the next change
+		_ = manager.Set("name", time.Now().String())
+		known.lock.RLock()
+		subscribers := len(known.subscribers)
+		known.lock.RUnlock()
+		manager.lock.RLock()
+		managerSubscribers := len(manager.subscribers)
+		manager.lock.RUnlock()
+		entry.lock.Lock()
+		links := len(entry.links)
+		entry.lock.Unlock()
+		return subscribers == 0 && managerSubscribers == 1 && links == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestIdentityMapLinkedInstancesConvergeUnderConcurrentChanges(t *testing.T) {
+	identityMap := NewIdentityMap("id")
+	me := parseWithIdentityMap(t, identityMap, `{"id":"1","name":"me"}`)
+	first := parseWithIdentityMap(t, identityMap, `{"id":"1"}`)
+	second := parseWithIdentityMap(t, identityMap, `{"id":"1"}`)
+
+	var wg sync.WaitGroup
+	for _, entity := range []*testEntity{first, second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range 100 {
+				name := fmt.Sprintf("%p/%d", entity, index)
+				entity.SetName(&name)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, *me.GetName(), *first.GetName())
+	assert.Equal(t, *me.GetName(), *second.GetName())
+	me.GetBackingStore().SetReturnOnlyChangedValues(true)
+	assert.Equal(t, []string{"name"}, keysOf(me.GetBackingStore().Enumerate()))
+}
+
+type nonBackedModel struct {
+}
+
+func (n *nonBackedModel) GetBackingStore() BackingStore {
+	return nil
+}
+
+type otherEntity struct {
+	backingStore BackingStore
+}
+
+func (o *otherEntity) GetBackingStore() BackingStore {
+	return o.backingStore
+}
@@ -1,9 +1,13 @@
 package store
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"strings"
+	"sync"
+	"weak"
 
 	"github.com/google/uuid"
 )
@@ -14,23 +18,55 @@ import (
 // `newVal` is the newly assigned value
 type BackingStoreSubscriber func(key string, oldVal interface{}, newVal interface{})
 
+// InMemoryBackingStore is a BackingStore keeping the values in memory, it is safe for concurrent use by multiple goroutines.
+// Subscribers are invoked outside of the store lock so they can read and write the store.
+// Changes made to nested backed models and to collections are tracked so the keys holding them are marked as changed.
 type InMemoryBackingStore struct {
+	lock                    sync.RWMutex
 	returnOnlyChangedValues bool
 	initializationCompleted bool
 	store                   map[string]interface{}
 	subscribers             map[string]BackingStoreSubscriber
 	changedValues           map[string]bool
+	// collections holds a copy of the slices and maps stored to detect changes made in place
+	collections map[string]interface{}
+	// propagating holds the keys whose nested changes are being notified, to stop the propagation in cyclic graphs
+	propagating map[string]bool
+	// transactions holds the snapshots taken at the start of the pending transactions
+	transactions []*BackingStoreSnapshot
+	// returnOnlyChangedValuesVersion counts the changes of returnOnlyChangedValues so readers can detect it was toggled meanwhile
+	returnOnlyChangedValuesVersion uint64
+	// versions counts the values stored by key so the subscriptions to nested models follow the latest value
+	versions map[string]uint64
+	// tracking serializes the subscriptions to nested models, it is never acquired while holding lock
+	tracking sync.Mutex
+	// tracked holds the nested stores subscribed to by key and the version of the value holding them, guarded by tracking
+	tracked map[string]trackedStores
+}
+
+// trackedStores holds the nested stores subscribed to for a version of the value of a key.
+type trackedStores struct {
+	version uint64
+	stores  []BackingStore
 }
 
 // NewInMemoryBackingStore returns a new instance of an in memory backing store
 // this function also provides an implementation of a BackingStoreFactory
 func NewInMemoryBackingStore() BackingStore {
+	return newInMemoryBackingStore()
+}
+
+func newInMemoryBackingStore() *InMemoryBackingStore {
 	return &InMemoryBackingStore{
 		returnOnlyChangedValues: false,
 		initializationCompleted: true,
 		store:                   make(map[string]interface{}),
 		subscribers:             make(map[string]BackingStoreSubscriber),
 		changedValues:           make(map[string]bool),
+		collections:             make(map[string]interface{}),
+		propagating:             make(map[string]bool),
+		versions:                make(map[string]uint64),
+		tracked:                 make(map[string]trackedStores),
 	}
 }
 
@@ -40,9 +76,16 @@ func (i *InMemoryBackingStore) Get(key string) (interface{}, error) {
 		return nil, errors.New("key cannot be an empty string")
 	}
 
+	if i.GetReturnOnlyChangedValues() {
+		i.refresh(make(map[*InMemoryBackingStore]bool), key)
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
 	objectVal := i.store[key]
 
-	if (i.GetReturnOnlyChangedValues() && i.changedValues[key]) || !i.GetReturnOnlyChangedValues() {
+	if (i.returnOnlyChangedValues && i.changedValues[key]) || !i.returnOnlyChangedValues {
 		return objectVal, nil
 	} else {
 		return nil, nil
@@ -55,23 +98,66 @@ func (i *InMemoryBackingStore) Set(key string, value interface{}) error {
 		return errors.New("key cannot be an empty string")
 	}
 
+	i.lock.Lock()
 	current := i.store[key]
 
 	// check if objects values have changed
-	if current == nil || hasChanged(current, value) {
-		// track changed key
-		i.changedValues[key] = i.GetInitializationCompleted()
+	if current != nil && !hasChanged(current, value) {
+		i.lock.Unlock()
+		return nil
+	}
 
-		// update changed values
-		i.store[key] = value
+	// track changed key
+	i.replace(key, current, value, i.initializationCompleted)
+	return nil
+}
 
-		// notify subs
-		for _, subscriber := range i.subscribers {
-			subscriber(key, current, value)
-		}
+// setWithChangedFlag sets the value and marks it with the change flag whatever the initialization state,
+// nothing happens when the store already holds the value with that flag.
+func (i *InMemoryBackingStore) setWithChangedFlag(key string, value interface{}, changed bool) {
+	i.lock.Lock()
+	current, ok := i.store[key]
+	if ok && i.changedValues[key] == changed && !valueChanged(current, value) {
+		i.lock.Unlock()
+		return
 	}
+	i.replace(key, current, value, changed)
+}
 
-	return nil
+// valueOf returns the value held by the key and its change flag, whatever the values returned by Get.
+func (i *InMemoryBackingStore) valueOf(key string) (interface{}, bool, bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	value, ok := i.store[key]
+	return value, i.changedValues[key], ok
+}
+
+// replace stores the value, tracks it and notifies the subscribers, the caller must hold the lock which is released.
+func (i *InMemoryBackingStore) replace(key string, current interface{}, value interface{}, changed bool) {
+	i.changedValues[key] = changed
+
+	// update changed values
+	i.store[key] = value
+	i.snapshotCollection(key, value)
+	version := i.nextVersion(key)
+	subscribers := i.subscribersSnapshot()
+	i.lock.Unlock()
+
+	i.retrack(key, version, value, changed)
+
+	// notify subs outside of the lock so they can use the store
+	for _, subscriber := range subscribers {
+		subscriber(key, current, value)
+	}
+}
+
+// subscribersSnapshot returns the current subscribers, the caller must hold the lock.
+func (i *InMemoryBackingStore) subscribersSnapshot() []BackingStoreSubscriber {
+	subscribers := make([]BackingStoreSubscriber, 0, len(i.subscribers))
+	for _, subscriber := range i.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+	return subscribers
 }
 
 func hasChanged(current interface{}, value interface{}) bool {
@@ -84,10 +170,15 @@ func hasChanged(current interface{}, value interface{}) bool {
 }
 
 func (i *InMemoryBackingStore) Enumerate() map[string]interface{} {
+	i.refresh(make(map[*InMemoryBackingStore]bool))
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
 	items := make(map[string]interface{})
 
 	for k, v := range i.store {
-		if !i.GetReturnOnlyChangedValues() || i.changedValues[k] { // change flag not set or object changed
+		if !i.returnOnlyChangedValues || i.changedValues[k] { // change flag not set or object changed
 			items[k] = v
 		}
 	}
@@ -96,6 +187,11 @@ func (i *InMemoryBackingStore) Enumerate() map[string]interface{} {
 }
 
 func (i *InMemoryBackingStore) EnumerateKeysForValuesChangedToNil() []string {
+	i.refresh(make(map[*InMemoryBackingStore]bool))
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
 	keys := make([]string, 0)
 	for k, v := range i.store {
 		valueOfV := reflect.ValueOf(v)
@@ -109,6 +205,8 @@ func (i *InMemoryBackingStore) EnumerateKeysForValuesChangedToNil() []string {
 
 func (i *InMemoryBackingStore) Subscribe(callback BackingStoreSubscriber) string {
 	id := uuid.New().String()
+	i.lock.Lock()
+	defer i.lock.Unlock()
 	i.subscribers[id] = callback
 	return id
 }
@@ -119,41 +217,605 @@ func (i *InMemoryBackingStore) SubscribeWithId(callback BackingStoreSubscriber,
 		return errors.New("subscriptionId cannot be an empty string")
 	}
 
+	i.lock.Lock()
+	defer i.lock.Unlock()
 	i.subscribers[subscriptionId] = callback
 
 	return nil
 }
 
+func (i *InMemoryBackingStore) SubscribeToKeys(callback BackingStoreSubscriber, keys ...string) string {
+	return i.Subscribe(filterKeys(callback, keys))
+}
+
+func (i *InMemoryBackingStore) Watch(ctx context.Context, keys ...string) (<-chan Change, context.CancelFunc) {
+	return watch(ctx, i, keys)
+}
+
 func (i *InMemoryBackingStore) Unsubscribe(subscriptionId string) error {
 	subscriptionId = strings.TrimSpace(subscriptionId)
 	if subscriptionId == "" {
 		return errors.New("subscriptionId cannot be an empty string")
 	}
 
+	i.lock.Lock()
+	defer i.lock.Unlock()
 	delete(i.subscribers, subscriptionId)
 
 	return nil
 }
 
 func (i *InMemoryBackingStore) Clear() {
+	i.lock.Lock()
+	versions := make(map[string]uint64, len(i.store))
 	for k := range i.store {
-		delete(i.store, k)
-		delete(i.changedValues, k) // changed values must be an element in the store
+		versions[k] = i.nextVersion(k)
+	}
+	i.store = make(map[string]interface{})
+	i.changedValues = make(map[string]bool) // changed values must be an element in the store
+	i.collections = make(map[string]interface{})
+	i.lock.Unlock()
+
+	for k, version := range versions {
+		i.retrack(k, version, nil, false)
 	}
 }
 
 func (i *InMemoryBackingStore) GetInitializationCompleted() bool {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
 	return i.initializationCompleted
 }
 
 func (i *InMemoryBackingStore) SetInitializationCompleted(val bool) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
 	i.initializationCompleted = val
 }
 
 func (i *InMemoryBackingStore) GetReturnOnlyChangedValues() bool {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
 	return i.returnOnlyChangedValues
 }
 
 func (i *InMemoryBackingStore) SetReturnOnlyChangedValues(val bool) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
 	i.returnOnlyChangedValues = val
+	i.returnOnlyChangedValuesVersion++
+}
+
+// returnsAllValues returns whether Get and Enumerate return all the values, and the version of that setting.
+func (i *InMemoryBackingStore) returnsAllValues() (uint64, bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	return i.returnOnlyChangedValuesVersion, !i.returnOnlyChangedValues
+}
+
+// ErrNoTransaction is returned when committing or rolling back a store without a pending transaction.
+var ErrNoTransaction = errors.New("no transaction was started on the backing store")
+
+func (i *InMemoryBackingStore) Snapshot() *BackingStoreSnapshot {
+	return i.snapshot(make(map[*InMemoryBackingStore]*BackingStoreSnapshot))
+}
+
+func (i *InMemoryBackingStore) snapshot(visited map[*InMemoryBackingStore]*BackingStoreSnapshot) *BackingStoreSnapshot {
+	if result, ok := visited[i]; ok {
+		return result
+	}
+	result := &BackingStoreSnapshot{
+		Values:        make(map[string]interface{}),
+		ChangedValues: make(map[string]bool),
+		Nested:        make([]NestedBackingStoreSnapshot, 0),
+	}
+	visited[i] = result
+
+	i.lock.RLock()
+	for k, v := range i.store {
+		result.Values[k] = copyValue(v)
+		result.ChangedValues[k] = i.changedValues[k]
+	}
+	i.lock.RUnlock()
+
+	// stores are compared by identity, comparing them as interfaces panics when their type is not comparable
+	added := make(map[*InMemoryBackingStore]bool)
+	for _, v := range result.Values {
+		for _, child := range childBackingStores(v) {
+			if memoryStore, ok := asInMemoryBackingStore(child); ok {
+				if added[memoryStore] {
+					continue
+				}
+				added[memoryStore] = true
+				store, ok := child.(TransactionalBackingStore)
+				if !ok {
+					store = memoryStore
+				}
+				result.Nested = append(result.Nested, NestedBackingStoreSnapshot{Store: store, Snapshot: memoryStore.snapshot(visited)})
+			} else if store, ok := child.(TransactionalBackingStore); ok && !containsStore(result.Nested, store) {
+				result.Nested = append(result.Nested, NestedBackingStoreSnapshot{Store: store, Snapshot: store.Snapshot()})
+			}
+		}
+	}
+	return result
+}
+
+// containsStore returns whether a snapshot of the store is already nested, stores whose type is not comparable are never found.
+func containsStore(nested []NestedBackingStoreSnapshot, store TransactionalBackingStore) bool {
+	if !reflect.TypeOf(store).Comparable() {
+		return false
+	}
+	for _, entry := range nested {
+		if reflect.TypeOf(entry.Store) == reflect.TypeOf(store) && entry.Store == store {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *InMemoryBackingStore) Restore(snapshot *BackingStoreSnapshot) error {
+	if snapshot == nil {
+		return errors.New("snapshot cannot be nil")
+	}
+	return i.restore(snapshot, make(map[*InMemoryBackingStore]bool))
+}
+
+func (i *InMemoryBackingStore) restore(snapshot *BackingStoreSnapshot, visited map[*InMemoryBackingStore]bool) error {
+	if visited[i] {
+		return nil
+	}
+	visited[i] = true
+
+	// nested stores are restored first so their notifications do not mark the restored keys as changed
+	for _, nested := range snapshot.Nested {
+		if nested.Store == nil || nested.Snapshot == nil {
+			continue
+		}
+		var err error
+		if memoryStore, ok := asInMemoryBackingStore(nested.Store); ok {
+			err = memoryStore.restore(nested.Snapshot, visited)
+		} else {
+			err = nested.Store.Restore(nested.Snapshot)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	i.lock.Lock()
+	previous := i.store
+	i.store = make(map[string]interface{}, len(snapshot.Values))
+	i.changedValues = make(map[string]bool, len(snapshot.Values))
+	i.collections = make(map[string]interface{})
+	for k, v := range snapshot.Values {
+		i.store[k] = copyValue(v)
+		i.changedValues[k] = snapshot.ChangedValues[k]
+		i.snapshotCollection(k, i.store[k])
+	}
+	restored := i.store
+	versions := make(map[string]uint64, len(previous)+len(restored))
+	for k := range previous {
+		versions[k] = i.nextVersion(k)
+	}
+	for k := range restored {
+		if _, ok := versions[k]; !ok {
+			versions[k] = i.nextVersion(k)
+		}
+	}
+	subscribers := i.subscribersSnapshot()
+	i.lock.Unlock()
+
+	for k, version := range versions {
+		i.retrack(k, version, restored[k], false)
+	}
+
+	for k, v := range previous {
+		current, ok := restored[k]
+		if ok && !valueChanged(v, current) {
+			continue
+		}
+		for _, subscriber := range subscribers {
+			subscriber(k, v, current)
+		}
+	}
+	for k, v := range restored {
+		if _, ok := previous[k]; ok {
+			continue
+		}
+		for _, subscriber := range subscribers {
+			subscriber(k, nil, v)
+		}
+	}
+	return nil
+}
+
+func (i *InMemoryBackingStore) Begin() {
+	snapshot := i.Snapshot()
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.transactions = append(i.transactions, snapshot)
+}
+
+func (i *InMemoryBackingStore) Commit() error {
+	_, err := i.endTransaction()
+	return err
+}
+
+func (i *InMemoryBackingStore) Rollback() error {
+	snapshot, err := i.endTransaction()
+	if err != nil {
+		return err
+	}
+	return i.Restore(snapshot)
+}
+
+// endTransaction removes the current transaction and returns the snapshot taken when it started.
+func (i *InMemoryBackingStore) endTransaction() (*BackingStoreSnapshot, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if len(i.transactions) == 0 {
+		return nil, ErrNoTransaction
+	}
+	snapshot := i.transactions[len(i.transactions)-1]
+	i.transactions = i.transactions[:len(i.transactions)-1]
+	return snapshot, nil
+}
+
+// copyValue returns a copy of the value when it is a collection so that changes made in place do not affect the copy.
+func copyValue(value interface{}) interface{} {
+	if result := copyCollection(value); result != nil {
+		return result
+	}
+	return value
+}
+
+// valueChanged returns whether the values differ, collections are compared item by item.
+func valueChanged(previous interface{}, current interface{}) bool {
+	if isCollection(previous) && isCollection(current) {
+		return collectionChanged(previous, current)
+	}
+	return hasChanged(previous, current)
+}
+
+// markChanged marks the keys as changed without notifying the subscribers, missing keys are set to nil.
+func (i *InMemoryBackingStore) markChanged(keys []string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for _, k := range keys {
+		if _, ok := i.store[k]; !ok {
+			i.store[k] = nil
+		}
+		i.changedValues[k] = true
+	}
+}
+
+// changes returns the keys of the changed values and all the values.
+func (i *InMemoryBackingStore) changes() ([]string, map[string]interface{}) {
+	i.refresh(make(map[*InMemoryBackingStore]bool))
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	keys := make([]string, 0)
+	values := make(map[string]interface{}, len(i.store))
+	for k, v := range i.store {
+		if i.changedValues[k] {
+			keys = append(keys, k)
+		}
+		values[k] = v
+	}
+	return keys, values
+}
+
+// inMemoryBackingStoreHolder is implemented by the stores built on top of an InMemoryBackingStore.
+type inMemoryBackingStoreHolder interface {
+	inMemoryBackingStore() *InMemoryBackingStore
+}
+
+// asInMemoryBackingStore returns the InMemoryBackingStore holding the state of the store, to track it across nested models.
+func asInMemoryBackingStore(store BackingStore) (*InMemoryBackingStore, bool) {
+	switch value := store.(type) {
+	case *InMemoryBackingStore:
+		return value, true
+	case inMemoryBackingStoreHolder:
+		return value.inMemoryBackingStore(), true
+	}
+	return nil, false
+}
+
+var backedModelType = reflect.TypeOf((*BackedModel)(nil)).Elem()
+
+// subscriptionId returns the id of the subscription of the store to the backing stores of the models held by the key.
+func (i *InMemoryBackingStore) subscriptionId(key string) string {
+	return fmt.Sprintf("%p/%s", i, key)
+}
+
+// nextVersion returns the version of a new value of the key, the caller must hold the lock.
+func (i *InMemoryBackingStore) nextVersion(key string) uint64 {
+	i.versions[key]++
+	return i.versions[key]
+}
+
+// retrack moves the subscriptions of the key to the nested models held by the value, unless a later version is already tracked
+// because a concurrent change of the key got there first.
+func (i *InMemoryBackingStore) retrack(key string, version uint64, value interface{}, changed bool) {
+	i.tracking.Lock()
+	defer i.tracking.Unlock()
+	previous := i.tracked[key]
+	if previous.version >= version {
+		return
+	}
+	i.untrack(key, previous.stores)
+	i.tracked[key] = trackedStores{version: version, stores: i.track(key, value, changed)}
+}
+
+// track subscribes to the backing stores of the models held by the value so that their changes mark the key as changed,
+// and returns them. The subscriptions hold the store weakly so nested models shared with longer lived models do not keep it alive,
+// they are removed on the next change once the store is collected.
+// The items of a changed collection are all marked as changed so the collection is serialized entirely.
+func (i *InMemoryBackingStore) track(key string, value interface{}, changed bool) []BackingStore {
+	id := i.subscriptionId(key)
+	parent := weak.Make(i)
+	children := childBackingStores(value)
+	for _, child := range children {
+		_ = child.SubscribeWithId(func(string, interface{}, interface{}) {
+			parent := parent.Value()
+			if parent == nil {
+				_ = child.Unsubscribe(id)
+				return
+			}
+			// values set while the nested model is being deserialized are not changes
+			if child.GetInitializationCompleted() {
+				parent.childChanged(key)
+			}
+		}, id)
+	}
+	if changed {
+		markCollectionItemsChanged(value)
+	}
+	return children
+}
+
+// untrack removes the subscriptions made by track to the stores.
+func (i *InMemoryBackingStore) untrack(key string, stores []BackingStore) {
+	id := i.subscriptionId(key)
+	for _, child := range stores {
+		_ = child.Unsubscribe(id)
+	}
+}
+
+// childChanged marks the key as changed after a change in a nested model and notifies the subscribers so the change propagates to the parents.
+func (i *InMemoryBackingStore) childChanged(key string) {
+	i.lock.Lock()
+	value, ok := i.store[key]
+	if !ok || !i.initializationCompleted || i.propagating[key] {
+		i.lock.Unlock()
+		return
+	}
+	alreadyChanged := i.changedValues[key]
+	i.changedValues[key] = true
+	i.propagating[key] = true
+	subscribers := i.subscribersSnapshot()
+	i.lock.Unlock()
+
+	if !alreadyChanged {
+		markCollectionItemsChanged(value)
+	}
+	for _, subscriber := range subscribers {
+		subscriber(key, value, value)
+	}
+
+	i.lock.Lock()
+	delete(i.propagating, key)
+	i.lock.Unlock()
+}
+
+// markAllChanged marks all the values of the store and of its nested models as changed.
+func (i *InMemoryBackingStore) markAllChanged(visited map[*InMemoryBackingStore]bool) {
+	if visited[i] {
+		return
+	}
+	visited[i] = true
+
+	i.lock.Lock()
+	values := make([]interface{}, 0, len(i.store))
+	for k, v := range i.store {
+		i.changedValues[k] = true
+		values = append(values, v)
+	}
+	i.lock.Unlock()
+
+	for _, v := range values {
+		for _, child := range childBackingStores(v) {
+			if memoryStore, ok := asInMemoryBackingStore(child); ok {
+				memoryStore.markAllChanged(visited)
+			}
+		}
+	}
+}
+
+// markCollectionItemsChanged marks all the values of the models held by a collection as changed.
+func markCollectionItemsChanged(value interface{}) {
+	if _, ok := value.(BackedModel); ok {
+		return
+	}
+	visited := make(map[*InMemoryBackingStore]bool)
+	for _, child := range childBackingStores(value) {
+		if memoryStore, ok := asInMemoryBackingStore(child); ok {
+			memoryStore.markAllChanged(visited)
+		}
+	}
+}
+
+// snapshotCollection keeps a copy of the value when it is a collection, the caller must hold the lock.
+func (i *InMemoryBackingStore) snapshotCollection(key string, value interface{}) {
+	if snapshot := copyCollection(value); snapshot != nil {
+		i.collections[key] = snapshot
+	} else {
+		delete(i.collections, key)
+	}
+}
+
+// refresh detects the changes made in place to the collections held by the keys, or all the keys when none is provided,
+// and to the models they hold.
+func (i *InMemoryBackingStore) refresh(visited map[*InMemoryBackingStore]bool, keys ...string) {
+	if visited[i] {
+		return
+	}
+	visited[i] = true
+
+	i.lock.RLock()
+	if len(keys) == 0 {
+		keys = make([]string, 0, len(i.store))
+		for k := range i.store {
+			keys = append(keys, k)
+		}
+	}
+	children := make([]BackingStore, 0)
+	for _, k := range keys {
+		children = append(children, childBackingStores(i.store[k])...)
+	}
+	i.lock.RUnlock()
+
+	// nested stores notify this store through their subscriptions when they changed
+	for _, child := range children {
+		if memoryStore, ok := asInMemoryBackingStore(child); ok {
+			memoryStore.refresh(visited)
+		}
+	}
+
+	type collectionChange struct {
+		key      string
+		previous interface{}
+		current  interface{}
+		version  uint64
+	}
+	changes := make([]collectionChange, 0)
+	i.lock.Lock()
+	for _, k := range keys {
+		snapshot, ok := i.collections[k]
+		if !ok || !collectionChanged(snapshot, i.store[k]) {
+			continue
+		}
+		changes = append(changes, collectionChange{k, snapshot, i.store[k], i.nextVersion(k)})
+		i.snapshotCollection(k, i.store[k])
+		i.changedValues[k] = i.changedValues[k] || i.initializationCompleted
+	}
+	changed := i.initializationCompleted
+	subscribers := i.subscribersSnapshot()
+	i.lock.Unlock()
+
+	for _, change := range changes {
+		i.retrack(change.key, change.version, change.current, changed)
+		for _, subscriber := range subscribers {
+			subscriber(change.key, change.previous, change.current)
+		}
+	}
+}
+
+// childBackingStores returns the backing stores of the models held by the value, directly or as items of a slice, an array or a map.
+func childBackingStores(value interface{}) []BackingStore {
+	if model, ok := value.(BackedModel); ok {
+		if store := backingStoreOf(model); store != nil {
+			return []BackingStore{store}
+		}
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return nil
+	}
+	if elem := v.Type().Elem(); elem.Kind() != reflect.Interface && !elem.Implements(backedModelType) {
+		return nil
+	}
+	stores := make([]BackingStore, 0)
+	appendItem := func(item reflect.Value) {
+		if model, ok := item.Interface().(BackedModel); ok {
+			if store := backingStoreOf(model); store != nil {
+				stores = append(stores, store)
+			}
+		}
+	}
+	if v.Kind() == reflect.Map {
+		iter := v.MapRange()
+		for iter.Next() {
+			appendItem(iter.Value())
+		}
+	} else {
+		for index := 0; index < v.Len(); index++ {
+			appendItem(v.Index(index))
+		}
+	}
+	return stores
+}
+
+func backingStoreOf(model BackedModel) BackingStore {
+	if v := reflect.ValueOf(model); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	return model.GetBackingStore()
+}
+
+// copyCollection returns a shallow copy of a slice or a map, nil for other values.
+func copyCollection(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(result, v)
+		return result.Interface()
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		result := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), iter.Value())
+		}
+		return result.Interface()
+	}
+	return nil
+}
+
+func isCollection(value interface{}) bool {
+	kind := reflect.ValueOf(value).Kind()
+	return kind == reflect.Slice || kind == reflect.Map
+}
+
+// collectionChanged returns whether the items of the collection differ from the copy made when it was stored.
+func collectionChanged(snapshot interface{}, value interface{}) bool {
+	s := reflect.ValueOf(snapshot)
+	v := reflect.ValueOf(value)
+	if s.Type() != v.Type() || s.Len() != v.Len() {
+		return true
+	}
+	if v.Kind() == reflect.Map {
+		iter := v.MapRange()
+		for iter.Next() {
+			previous := s.MapIndex(iter.Key())
+			if !previous.IsValid() || !sameItem(previous, iter.Value()) {
+				return true
+			}
+		}
+		return false
+	}
+	for index := 0; index < v.Len(); index++ {
+		if !sameItem(s.Index(index), v.Index(index)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameItem compares the items by identity when they are comparable, models held by pointers are compared by reference.
+func sameItem(a reflect.Value, b reflect.Value) bool {
+	if a.Comparable() && b.Comparable() {
+		return a.Equal(b)
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
 }
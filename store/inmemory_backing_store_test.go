@@ -1,8 +1,13 @@
 package store
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/microsoft/kiota-abstractions-go/serialization"
@@ -221,6 +226,7 @@ type testEntity struct {
 	name           *string
 	phoneNumbers   []string
 	items          []*testEntity
+	manager        *testEntity
 	backingStore   BackingStore
 }
 
@@ -237,11 +243,89 @@ func (t *testEntity) GetBackingStore() BackingStore {
 }
 
 func (t *testEntity) Serialize(writer serialization.SerializationWriter) error {
-	panic("implement me")
+	if err := writer.WriteStringValue("id", t.GetId()); err != nil {
+		return err
+	}
+	if err := writer.WriteStringValue("name", t.GetName()); err != nil {
+		return err
+	}
+	if err := writer.WriteCollectionOfStringValues("phoneNumbers", t.GetPhoneNumbers()); err != nil {
+		return err
+	}
+	if items := t.GetItems(); items != nil {
+		cast := make([]serialization.Parsable, len(items))
+		for i, item := range items {
+			cast[i] = item
+		}
+		if err := writer.WriteCollectionOfObjectValues("items", cast); err != nil {
+			return err
+		}
+	}
+	if manager := t.GetManager(); manager != nil {
+		if err := writer.WriteObjectValue("manager", manager); err != nil {
+			return err
+		}
+	}
+	return writer.WriteAdditionalData(t.GetAdditionalData())
 }
 
 func (t *testEntity) GetFieldDeserializers() map[string]func(serialization.ParseNode) error {
-	panic("implement me")
+	return map[string]func(serialization.ParseNode) error{
+		"id": func(n serialization.ParseNode) error {
+			val, err := n.GetStringValue()
+			if err != nil {
+				return err
+			}
+			t.SetId(val)
+			return nil
+		},
+		"name": func(n serialization.ParseNode) error {
+			val, err := n.GetStringValue()
+			if err != nil {
+				return err
+			}
+			t.SetName(val)
+			return nil
+		},
+		"phoneNumbers": func(n serialization.ParseNode) error {
+			val, err := n.GetCollectionOfPrimitiveValues("string")
+			if err != nil {
+				return err
+			}
+			if val != nil {
+				numbers := make([]string, len(val))
+				for i, v := range val {
+					numbers[i] = *(v.(*string))
+				}
+				t.SetPhoneNumbers(numbers)
+			}
+			return nil
+		},
+		"items": func(n serialization.ParseNode) error {
+			val, err := n.GetCollectionOfObjectValues(createTestEntityFromDiscriminatorValue)
+			if err != nil {
+				return err
+			}
+			if val != nil {
+				items := make([]*testEntity, len(val))
+				for i, v := range val {
+					items[i] = v.(*testEntity)
+				}
+				t.SetItems(items)
+			}
+			return nil
+		},
+		"manager": func(n serialization.ParseNode) error {
+			val, err := n.GetObjectValue(createTestEntityFromDiscriminatorValue)
+			if err != nil {
+				return err
+			}
+			if val != nil {
+				t.SetManager(val.(*testEntity))
+			}
+			return nil
+		},
+	}
 }
 
 func (t *testEntity) GetId() *string {
@@ -289,8 +373,438 @@ func (t *testEntity) SetPhoneNumbers(numbers []string) {
 	}
 }
 
+func (t *testEntity) GetItems() []*testEntity {
+	val, _ := t.GetBackingStore().Get("items")
+	if val != nil {
+		return val.([]*testEntity)
+	}
+	return nil
+}
+
+func (t *testEntity) SetItems(items []*testEntity) {
+	err := t.GetBackingStore().Set("items", items)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (t *testEntity) GetManager() *testEntity {
+	val, _ := t.GetBackingStore().Get("manager")
+	if val != nil {
+		return val.(*testEntity)
+	}
+	return nil
+}
+
+func (t *testEntity) SetManager(manager *testEntity) {
+	err := t.GetBackingStore().Set("manager", manager)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func createTestEntityFromDiscriminatorValue(parseNode serialization.ParseNode) (serialization.Parsable, error) {
+	return NewTestEntity(), nil
+}
+
 func NewTestEntity() *testEntity {
 	return &testEntity{
 		backingStore: BackingStoreFactoryInstance(),
 	}
 }
+
+func TestConcurrentAccessToStore(t *testing.T) {
+	memoryStore := NewInMemoryBackingStore()
+	var count int64
+	var countLock sync.Mutex
+	memoryStore.Subscribe(func(key string, oldVal interface{}, newVal interface{}) {
+		countLock.Lock()
+		count++
+		countLock.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				key := fmt.Sprintf("key%d", i%10)
+				assert.Nil(t, memoryStore.Set(key, fmt.Sprintf("%d-%d", worker, i)))
+				_, err := memoryStore.Get(key)
+				assert.Nil(t, err)
+				memoryStore.Enumerate()
+				memoryStore.EnumerateKeysForValuesChangedToNil()
+				memoryStore.SetReturnOnlyChangedValues(i%2 == 0)
+				memoryStore.GetReturnOnlyChangedValues()
+				memoryStore.SetInitializationCompleted(i%3 != 0)
+				memoryStore.GetInitializationCompleted()
+				id := memoryStore.Subscribe(func(key string, oldVal interface{}, newVal interface{}) {})
+				assert.Nil(t, memoryStore.Unsubscribe(id))
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	memoryStore.SetReturnOnlyChangedValues(false)
+	assert.Equal(t, 10, len(memoryStore.Enumerate()))
+	countLock.Lock()
+	defer countLock.Unlock()
+	assert.Equal(t, int64(800), count)
+}
+
+func TestConcurrentClearOfStore(t *testing.T) {
+	memoryStore := NewInMemoryBackingStore()
+	var wg sync.WaitGroup
+	for worker := 0; worker < 4; worker++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				assert.Nil(t, memoryStore.Set(fmt.Sprintf("key%d", i), i))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				memoryStore.Clear()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSubscribersCanUseTheStore(t *testing.T) {
+	memoryStore := NewInMemoryBackingStore()
+	memoryStore.Subscribe(func(key string, oldVal interface{}, newVal interface{}) {
+		if key == "name" {
+			value, _ := memoryStore.Get("name")
+			_ = memoryStore.Set("echo", value)
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Nil(t, memoryStore.Set("name", "Michael"))
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the subscriber deadlocked on the store")
+	}
+	value, err := memoryStore.Get("echo")
+	assert.Nil(t, err)
+	assert.Equal(t, "Michael", value)
+}
+
+// newInitializedTestEntity returns an entity whose values were set before the initialization completed, as during deserialization.
+func newInitializedTestEntity(id string, init func(entity *testEntity)) *testEntity {
+	entity := NewTestEntity()
+	entity.GetBackingStore().SetInitializationCompleted(false)
+	entity.SetId(&id)
+	if init != nil {
+		init(entity)
+	}
+	entity.GetBackingStore().SetInitializationCompleted(true)
+	entity.GetBackingStore().SetReturnOnlyChangedValues(true)
+	return entity
+}
+
+func TestChangesToNestedModelsMarkTheParentAsChanged(t *testing.T) {
+	manager := newInitializedTestEntity("2", nil)
+	parent := newInitializedTestEntity("1", func(entity *testEntity) {
+		entity.SetManager(manager)
+	})
+	grandParent := newInitializedTestEntity("0", func(entity *testEntity) {
+		entity.SetManager(parent)
+	})
+	assert.Empty(t, grandParent.GetBackingStore().Enumerate())
+	assert.Empty(t, parent.GetBackingStore().Enumerate())
+
+	var notifiedKeys []string
+	grandParent.GetBackingStore().Subscribe(func(key string, oldVal interface{}, newVal interface{}) {
+		notifiedKeys = append(notifiedKeys, key)
+	})
+	name := "Jeane"
+	manager.SetName(&name)
+
+	assert.Equal(t, map[string]interface{}{"manager": parent}, grandParent.GetBackingStore().Enumerate())
+	assert.Equal(t, map[string]interface{}{"manager": manager}, parent.GetBackingStore().Enumerate())
+	assert.Equal(t, map[string]interface{}{"name": &name}, manager.GetBackingStore().Enumerate())
+	assert.Equal(t, []string{"manager"}, notifiedKeys)
+}
+
+func TestReplacedNestedModelsAreNoLongerTracked(t *testing.T) {
+	previous := newInitializedTestEntity("2", nil)
+	parent := newInitializedTestEntity("1", func(entity *testEntity) {
+		entity.SetManager(previous)
+		entity.SetManager(newInitializedTestEntity("3", nil))
+	})
+
+	name := "Jeane"
+	previous.SetName(&name)
+	assert.Empty(t, parent.GetBackingStore().Enumerate())
+
+	parent.GetBackingStore().Clear()
+	parent.GetManager()
+	assert.Empty(t, parent.GetBackingStore().Enumerate())
+}
+
+func TestConcurrentlyReplacedNestedModelsAreNoLongerTracked(t *testing.T) {
+	parent := newInMemoryBackingStore()
+	managers := make([]*testEntity, 0)
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		workerManagers := make([]*testEntity, 200)
+		for i := range workerManagers {
+			workerManagers[i] = newInitializedTestEntity(fmt.Sprintf("%d-%d", worker, i), nil)
+		}
+		managers = append(managers, workerManagers...)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, manager := range workerManagers {
+				assert.Nil(t, parent.Set("manager", manager))
+			}
+		}()
+	}
+	wg.Wait()
+
+	current, err := parent.Get("manager")
+	assert.Nil(t, err)
+	id := parent.subscriptionId("manager")
+	for index, manager := range managers {
+		managerStore, _ := asInMemoryBackingStore(manager.GetBackingStore())
+		managerStore.lock.RLock()
+		_, subscribed := managerStore.subscribers[id]
+		managerStore.lock.RUnlock()
+		assert.Equal(t, manager == current, subscribed, "manager %d", index)
+	}
+}
+
+func TestChangesToCollectionItemsMarkTheCollectionAsChanged(t *testing.T) {
+	first := newInitializedTestEntity("2", nil)
+	second := newInitializedTestEntity("3", nil)
+	parent := newInitializedTestEntity("1", func(entity *testEntity) {
+		entity.SetItems([]*testEntity{first, second})
+	})
+	assert.Empty(t, parent.GetBackingStore().Enumerate())
+
+	name := "Jeane"
+	second.SetName(&name)
+
+	changes := parent.GetBackingStore().Enumerate()
+	assert.Equal(t, []string{"items"}, keysOf(changes))
+	// the other items are serialized entirely with the collection
+	assert.Equal(t, "2", *first.GetId())
+	assert.Equal(t, "3", *second.GetId())
+}
+
+func TestCollectionsChangedInPlaceAreMarkedAsChanged(t *testing.T) {
+	first := newInitializedTestEntity("2", nil)
+	parent := newInitializedTestEntity("1", func(entity *testEntity) {
+		entity.SetPhoneNumbers([]string{"+1234", "+2345"})
+		entity.SetItems([]*testEntity{first})
+		entity.SetAdditionalData(map[string]interface{}{})
+	})
+	parent.GetBackingStore().SetReturnOnlyChangedValues(false)
+	parent.GetPhoneNumbers()[0] = "+3456"
+	parent.GetBackingStore().SetReturnOnlyChangedValues(true)
+
+	assert.Equal(t, []string{"+3456", "+2345"}, parent.GetPhoneNumbers())
+	assert.Nil(t, parent.GetItems())
+
+	parent.GetBackingStore().SetReturnOnlyChangedValues(false)
+	replacement := NewTestEntity()
+	parent.GetItems()[0] = replacement
+	parent.GetBackingStore().SetReturnOnlyChangedValues(true)
+	assert.Equal(t, []string{"items", "phoneNumbers"}, keysOf(parent.GetBackingStore().Enumerate()))
+
+	// the replacement is tracked after the change was detected
+	grandParent := newInitializedTestEntity("0", func(entity *testEntity) {
+		entity.SetManager(parent)
+	})
+	name := "Jeane"
+	replacement.SetName(&name)
+	assert.Equal(t, []string{"manager"}, keysOf(grandParent.GetBackingStore().Enumerate()))
+}
+
+func TestInPlaceChangesOfNestedCollectionsPropagateToTheParents(t *testing.T) {
+	manager := newInitializedTestEntity("2", func(entity *testEntity) {
+		entity.SetPhoneNumbers([]string{"+1234"})
+	})
+	parent := newInitializedTestEntity("1", func(entity *testEntity) {
+		entity.SetManager(manager)
+	})
+
+	manager.GetBackingStore().SetReturnOnlyChangedValues(false)
+	manager.GetPhoneNumbers()[0] = "+3456"
+	manager.GetBackingStore().SetReturnOnlyChangedValues(true)
+
+	assert.Equal(t, manager, parent.GetManager())
+}
+
+func TestCyclicModelsDoNotLoop(t *testing.T) {
+	first := newInitializedTestEntity("1", nil)
+	second := newInitializedTestEntity("2", func(entity *testEntity) {
+		entity.SetManager(first)
+	})
+	first.GetBackingStore().SetInitializationCompleted(false)
+	first.SetManager(second)
+	first.GetBackingStore().SetInitializationCompleted(true)
+
+	name := "Jeane"
+	first.SetName(&name)
+
+	assert.Equal(t, []string{"manager", "name"}, keysOf(first.GetBackingStore().Enumerate()))
+	assert.Equal(t, []string{"manager"}, keysOf(second.GetBackingStore().Enumerate()))
+}
+
+func keysOf(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestRestoresSnapshots(t *testing.T) {
+	entity := newInitializedTestEntity("1", func(entity *testEntity) {
+		name := "Michael"
+		entity.SetName(&name)
+	})
+	snapshot := entity.GetBackingStore().(TransactionalBackingStore).Snapshot()
+
+	var notified []string
+	entity.GetBackingStore().Subscribe(func(key string, oldVal interface{}, newVal interface{}) {
+		notified = append(notified, key)
+	})
+	entity.SetName(nil)
+	entity.SetPhoneNumbers([]string{"+1234"})
+	assert.Equal(t, []string{"name"}, entity.GetBackingStore().EnumerateKeysForValuesChangedToNil())
+	notified = nil
+
+	assert.Nil(t, entity.GetBackingStore().(TransactionalBackingStore).Restore(snapshot))
+
+	sort.Strings(notified)
+	assert.Equal(t, []string{"name", "phoneNumbers"}, notified)
+	assert.Empty(t, entity.GetBackingStore().Enumerate())
+	assert.Empty(t, entity.GetBackingStore().EnumerateKeysForValuesChangedToNil())
+	entity.GetBackingStore().SetReturnOnlyChangedValues(false)
+	assert.Equal(t, "Michael", *entity.GetName())
+	assert.Nil(t, entity.GetPhoneNumbers())
+	assert.NotNil(t, entity.GetBackingStore().(TransactionalBackingStore).Restore(nil))
+}
+
+// unhashableBackingStore is a store whose type cannot be used as a map key.
+type unhashableBackingStore struct {
+	*InMemoryBackingStore
+	tags map[string]string
+}
+
+func TestSnapshotsNestedStoresWhoseTypeIsNotComparable(t *testing.T) {
+	manager := &testEntity{backingStore: unhashableBackingStore{newInMemoryBackingStore(), map[string]string{}}}
+	parent := newInitializedTestEntity("1", func(entity *testEntity) {
+		entity.SetManager(manager)
+	})
+	store := parent.GetBackingStore().(TransactionalBackingStore)
+
+	snapshot := store.Snapshot()
+	assert.Len(t, snapshot.Nested, 1)
+	name := "Jeane"
+	manager.SetName(&name)
+	assert.Nil(t, store.Restore(snapshot))
+	assert.Nil(t, manager.GetName())
+}
+
+func TestRollsBackNestedAndInPlaceChanges(t *testing.T) {
+	manager := newInitializedTestEntity("2", nil)
+	parent := newInitializedTestEntity("1", func(entity *testEntity) {
+		entity.SetManager(manager)
+		entity.SetPhoneNumbers([]string{"+1234"})
+	})
+	store := parent.GetBackingStore().(TransactionalBackingStore)
+
+	store.Begin()
+	name := "Jeane"
+	manager.SetName(&name)
+	store.SetReturnOnlyChangedValues(false)
+	parent.GetPhoneNumbers()[0] = "+3456"
+	store.SetReturnOnlyChangedValues(true)
+	assert.Equal(t, []string{"manager", "phoneNumbers"}, keysOf(store.Enumerate()))
+
+	assert.Nil(t, store.Rollback())
+
+	assert.Empty(t, store.Enumerate())
+	assert.Empty(t, manager.GetBackingStore().Enumerate())
+	store.SetReturnOnlyChangedValues(false)
+	assert.Equal(t, []string{"+1234"}, parent.GetPhoneNumbers())
+	assert.Nil(t, parent.GetManager().GetName())
+	store.SetReturnOnlyChangedValues(true)
+
+	// the nested model is still tracked after the rollback
+	manager.SetName(&name)
+	assert.Equal(t, []string{"manager"}, keysOf(store.Enumerate()))
+}
+
+func TestCommitsNestedTransactions(t *testing.T) {
+	entity := newInitializedTestEntity("1", nil)
+	store := entity.GetBackingStore().(TransactionalBackingStore)
+	first := "first"
+	second := "second"
+
+	store.Begin()
+	entity.SetName(&first)
+	store.Begin()
+	entity.SetName(&second)
+	assert.Nil(t, store.Rollback())
+	assert.Equal(t, "first", *entity.GetName())
+	assert.Nil(t, store.Commit())
+	assert.Equal(t, "first", *entity.GetName())
+
+	assert.Equal(t, ErrNoTransaction, store.Commit())
+	assert.Equal(t, ErrNoTransaction, store.Rollback())
+}
+
+func TestRunInTransactionRequiresATransactionalStore(t *testing.T) {
+	assert.Implements(t, (*TransactionalBackingStore)(nil), NewInMemoryBackingStore())
+	assert.Implements(t, (*TransactionalBackingStore)(nil), NewPersistentBackingStore(nil, "me"))
+	called := false
+	err := RunInTransaction(&plainBackingStore{NewInMemoryBackingStore()}, func() error {
+		called = true
+		return nil
+	})
+	assert.Equal(t, ErrNotTransactional, err)
+	assert.False(t, called)
+}
+
+func TestRunInTransaction(t *testing.T) {
+	entity := newInitializedTestEntity("1", nil)
+	name := "Jeane"
+	expected := errors.New("validation failed")
+
+	err := RunInTransaction(entity.GetBackingStore(), func() error {
+		entity.SetName(&name)
+		return expected
+	})
+	assert.Equal(t, expected, err)
+	assert.Nil(t, entity.GetName())
+
+	assert.Panics(t, func() {
+		_ = RunInTransaction(entity.GetBackingStore(), func() error {
+			entity.SetName(&name)
+			panic("failure")
+		})
+	})
+	assert.Nil(t, entity.GetName())
+
+	assert.Nil(t, RunInTransaction(entity.GetBackingStore(), func() error {
+		entity.SetName(&name)
+		return nil
+	}))
+	assert.Equal(t, "Jeane", *entity.GetName())
+	assert.Equal(t, ErrNoTransaction, entity.GetBackingStore().(TransactionalBackingStore).Commit())
+}
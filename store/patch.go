@@ -0,0 +1,182 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+)
+
+const (
+	// JsonPatchContentType is the content type of RFC 6902 JSON Patch documents.
+	JsonPatchContentType = "application/json-patch+json"
+	// MergePatchContentType is the content type of RFC 7396 JSON Merge Patch documents.
+	MergePatchContentType = "application/merge-patch+json"
+)
+
+var errModelNotBacked = errors.New("the model does not implement BackedModel, its changes are not tracked")
+
+// JsonPatchOperation represents an operation of an RFC 6902 JSON Patch document.
+type JsonPatchOperation struct {
+	// Op is the operation to perform, add, replace or remove.
+	Op string `json:"op"`
+	// Path is the JSON Pointer to the member the operation applies to.
+	Path string `json:"path"`
+	// Value is the value to add or replace, it is not written for remove operations.
+	Value any `json:"value,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, the value of add and replace operations is written even when it is nil.
+func (o JsonPatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+	return json.Marshal(struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value"`
+	}{o.Op, o.Path, o.Value})
+}
+
+// nativeValueWriterFactory returns writers building native Go values.
+type nativeValueWriterFactory struct {
+}
+
+func (f *nativeValueWriterFactory) GetValidContentType() (string, error) {
+	return "application/json", nil
+}
+
+func (f *nativeValueWriterFactory) GetSerializationWriter(contentType string) (serialization.SerializationWriter, error) {
+	return serialization.NewNativeSerializationWriter(), nil
+}
+
+// GetMergePatch returns the RFC 7396 JSON Merge Patch document describing the changes of the model.
+// Nested models only contain their changes, collections are written entirely and values changed to nil are written as null.
+func GetMergePatch(model serialization.Parsable) (map[string]any, error) {
+	if _, ok := model.(BackedModel); !ok {
+		return nil, errModelNotBacked
+	}
+	writer, err := NewBackingStoreSerializationWriterProxyFactory(&nativeValueWriterFactory{}).GetSerializationWriter("application/json")
+	if err != nil {
+		return nil, err
+	}
+	return serializeToMap(writer, model)
+}
+
+// GetMergePatchContent returns the RFC 7396 JSON Merge Patch document describing the changes of the model serialized as JSON.
+func GetMergePatchContent(model serialization.Parsable) ([]byte, error) {
+	document, err := GetMergePatch(model)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
+
+// GetJsonPatch returns the RFC 6902 JSON Patch operations describing the changes of the model.
+// Changed nested models produce operations for their changed members, values are set with add operations which replace existing members
+// and values changed to nil are removed, as in a JSON Merge Patch. Use GetJsonPatchWithNullValues when the members must be kept with a null value.
+func GetJsonPatch(model serialization.Parsable) ([]JsonPatchOperation, error) {
+	return getJsonPatch(model, false)
+}
+
+// GetJsonPatchWithNullValues returns the RFC 6902 JSON Patch operations describing the changes of the model,
+// values changed to nil are set to null with replace operations instead of being removed.
+func GetJsonPatchWithNullValues(model serialization.Parsable) ([]JsonPatchOperation, error) {
+	return getJsonPatch(model, true)
+}
+
+func getJsonPatch(model serialization.Parsable, keepNullValues bool) ([]JsonPatchOperation, error) {
+	changes, err := GetMergePatch(model)
+	if err != nil {
+		return nil, err
+	}
+	writer := serialization.NewNativeSerializationWriter()
+	err = writer.SetOnBeforeSerialization(func(parsable serialization.Parsable) error {
+		if backedModel, ok := parsable.(BackedModel); ok && backedModel.GetBackingStore() != nil {
+			backedModel.GetBackingStore().SetReturnOnlyChangedValues(false)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	full, err := serializeToMap(writer, model)
+	if err != nil {
+		return nil, err
+	}
+	operations := make([]JsonPatchOperation, 0)
+	appendJsonPatchOperations(&operations, "", changes, full, keepNullValues)
+	return operations, nil
+}
+
+// GetJsonPatchContent returns the RFC 6902 JSON Patch operations describing the changes of the model serialized as JSON.
+func GetJsonPatchContent(model serialization.Parsable) ([]byte, error) {
+	operations, err := GetJsonPatch(model)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(operations)
+}
+
+// GetJsonPatchWithNullValuesContent returns the operations of GetJsonPatchWithNullValues serialized as JSON.
+func GetJsonPatchWithNullValuesContent(model serialization.Parsable) ([]byte, error) {
+	operations, err := GetJsonPatchWithNullValues(model)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(operations)
+}
+
+func serializeToMap(writer serialization.SerializationWriter, model serialization.Parsable) (map[string]any, error) {
+	if err := writer.WriteObjectValue("", model); err != nil {
+		return nil, err
+	}
+	nativeWriter, ok := writer.(*serialization.NativeSerializationWriter)
+	if !ok {
+		return nil, errors.New("unexpected serialization writer type")
+	}
+	result, _ := nativeWriter.GetValue().(map[string]any)
+	if result == nil {
+		result = make(map[string]any)
+	}
+	return result, nil
+}
+
+// appendJsonPatchOperations appends the operations for the changes, objects only containing some of their members are nested changes.
+// Nil values are removed unless keepNullValues is set, in which case they are replaced with null.
+func appendJsonPatchOperations(operations *[]JsonPatchOperation, path string, changes map[string]any, full map[string]any, keepNullValues bool) {
+	keys := make([]string, 0, len(changes))
+	for k := range changes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		value := changes[k]
+		memberPath := path + "/" + escapeJsonPointer(k)
+		if value == nil {
+			if keepNullValues {
+				*operations = append(*operations, JsonPatchOperation{Op: "replace", Path: memberPath})
+			} else {
+				*operations = append(*operations, JsonPatchOperation{Op: "remove", Path: memberPath})
+			}
+			continue
+		}
+		if changedObject, ok := value.(map[string]any); ok {
+			if fullObject, ok := full[k].(map[string]any); ok && !reflect.DeepEqual(changedObject, fullObject) {
+				appendJsonPatchOperations(operations, memberPath, changedObject, fullObject, keepNullValues)
+				continue
+			}
+		}
+		*operations = append(*operations, JsonPatchOperation{Op: "add", Path: memberPath, Value: value})
+	}
+}
+
+func escapeJsonPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
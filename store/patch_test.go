@@ -0,0 +1,96 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseTestEntity(t *testing.T, content string) *testEntity {
+	factory := NewBackingStoreParseNodeFactory(&nativeJsonParseNodeFactory{})
+	node, err := factory.GetRootParseNode("application/json", []byte(content))
+	assert.Nil(t, err)
+	result, err := node.GetObjectValue(createTestEntityFromDiscriminatorValue)
+	assert.Nil(t, err)
+	return result.(*testEntity)
+}
+
+func TestGetsMergePatchOfNestedChanges(t *testing.T) {
+	entity := parseTestEntity(t, `{"id":"1","name":"parent","phoneNumbers":["+1234"],"manager":{"id":"2","name":"boss"}}`)
+
+	document, err := GetMergePatch(entity)
+	assert.Nil(t, err)
+	assert.Empty(t, document)
+
+	entity.SetName(nil)
+	entity.SetPhoneNumbers([]string{"+1234", "+2345"})
+	managerName := "new boss"
+	entity.GetManager().SetName(&managerName)
+
+	content, err := GetMergePatchContent(entity)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"name":null,"phoneNumbers":["+1234","+2345"],"manager":{"name":"new boss"}}`, string(content))
+}
+
+func TestGetsJsonPatchOfNestedChanges(t *testing.T) {
+	entity := parseTestEntity(t, `{"id":"1","name":"parent","manager":{"id":"2","name":"boss"},"items":[{"id":"3"}]}`)
+
+	entity.SetName(nil)
+	managerName := "new boss"
+	entity.GetManager().SetName(&managerName)
+	entity.GetManager().SetPhoneNumbers([]string{"+1234"})
+	itemName := "item"
+	entity.GetItems()[0].SetName(&itemName)
+
+	operations, err := GetJsonPatch(entity)
+	assert.Nil(t, err)
+	assert.Equal(t, []JsonPatchOperation{
+		{Op: "add", Path: "/items", Value: []any{map[string]any{"id": "3", "name": "item"}}},
+		{Op: "add", Path: "/manager/name", Value: "new boss"},
+		{Op: "add", Path: "/manager/phoneNumbers", Value: []any{"+1234"}},
+		{Op: "remove", Path: "/name"},
+	}, operations)
+}
+
+func TestGetsJsonPatchOfReplacedNestedModels(t *testing.T) {
+	entity := parseTestEntity(t, `{"id":"1","manager":{"id":"2","name":"boss"}}`)
+
+	manager := NewTestEntity()
+	id := "3"
+	manager.SetId(&id)
+	entity.SetManager(manager)
+	entity.SetAdditionalData(map[string]interface{}{"a/b~c": "value"})
+
+	content, err := GetJsonPatchContent(entity)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"op":"add","path":"/a~1b~0c","value":"value"},{"op":"add","path":"/manager","value":{"id":"3"}}]`, string(content))
+}
+
+func TestPatchRequiresABackedModel(t *testing.T) {
+	_, err := GetMergePatch(&nonBackedEntity{})
+	assert.NotNil(t, err)
+	_, err = GetJsonPatch(&nonBackedEntity{})
+	assert.NotNil(t, err)
+}
+
+type nonBackedEntity struct {
+	testEntity
+}
+
+func (n *nonBackedEntity) GetBackingStore() {
+}
+
+func TestGetsJsonPatchKeepingNullValues(t *testing.T) {
+	entity := parseTestEntity(t, `{"id":"1","name":"parent","manager":{"id":"2","name":"boss"}}`)
+
+	entity.SetName(nil)
+	entity.GetManager().SetName(nil)
+
+	content, err := GetJsonPatchContent(entity)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"op":"remove","path":"/manager/name"},{"op":"remove","path":"/name"}]`, string(content))
+
+	content, err = GetJsonPatchWithNullValuesContent(entity)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"op":"replace","path":"/manager/name","value":null},{"op":"replace","path":"/name","value":null}]`, string(content))
+}
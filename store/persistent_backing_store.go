@@ -0,0 +1,434 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+)
+
+// DefaultPersistentBackingStoreSaveDelay is the delay after a change before a PersistentBackingStore saves its state.
+const DefaultPersistentBackingStoreSaveDelay = 100 * time.Millisecond
+
+// ErrReturnOnlyChangedValues is returned when saving a model whose backing stores return only their changed values,
+// as while the model is serialized for a request, the flag is left unchanged so the request body is not altered.
+var ErrReturnOnlyChangedValues = errors.New("the backing store of the model returns only its changed values, its state cannot be saved")
+
+// PersistentBackingStore is a BackingStore saving the values, the change flags and the initialization state of the model it backs in a BackingStoreStorage.
+// Values are saved through the serialization of the model, which must be bound with Bind or Load, the state is then saved after the changes.
+// Every save serializes the whole model and replaces the saved state, which DirectoryBackingStoreStorage writes and syncs to disk,
+// so the changes made within the save delay are saved together, see SetSaveDelay and Flush.
+type PersistentBackingStore struct {
+	*InMemoryBackingStore
+	storage  BackingStoreStorage
+	saveLock sync.Mutex
+	lock     sync.RWMutex
+	loading  atomic.Bool
+	id       string
+	model    serialization.Parsable
+	// lastSaveError holds the error of the last save made after a change, since subscribers cannot return errors
+	lastSaveError error
+	saveDelay     time.Duration
+	// pending is set when changes were not saved yet, saveTimer is the timer saving them
+	pending   bool
+	saveTimer *time.Timer
+}
+
+// persistedBackingStoreState is the document saved in the storage.
+type persistedBackingStoreState struct {
+	InitializationCompleted bool              `json:"initializationCompleted"`
+	Value                   any               `json:"value"`
+	Changes                 *persistedChanges `json:"changes,omitempty"`
+}
+
+// persistedChanges holds the change flags of a store and of the stores of its nested models.
+type persistedChanges struct {
+	Changed []string                       `json:"changed,omitempty"`
+	Nested  map[string]*persistedChanges   `json:"nested,omitempty"`
+	Items   map[string][]*persistedChanges `json:"items,omitempty"`
+}
+
+func (c *persistedChanges) isEmpty() bool {
+	return c == nil || len(c.Changed) == 0 && len(c.Nested) == 0 && len(c.Items) == 0
+}
+
+// NewPersistentBackingStore returns a new instance of a persistent backing store saving its state under the id.
+func NewPersistentBackingStore(storage BackingStoreStorage, id string) *PersistentBackingStore {
+	result := &PersistentBackingStore{
+		InMemoryBackingStore: newInMemoryBackingStore(),
+		storage:              storage,
+		id:                   id,
+		saveDelay:            DefaultPersistentBackingStoreSaveDelay,
+	}
+	result.InMemoryBackingStore.Subscribe(func(string, interface{}, interface{}) {
+		result.autoSave()
+	})
+	return result
+}
+
+// NewPersistentBackingStoreFactory returns a BackingStoreFactory creating persistent backing stores with random ids.
+func NewPersistentBackingStoreFactory(storage BackingStoreStorage) BackingStoreFactory {
+	return func() BackingStore {
+		return NewPersistentBackingStore(storage, uuid.New().String())
+	}
+}
+
+func (p *PersistentBackingStore) inMemoryBackingStore() *InMemoryBackingStore {
+	return p.InMemoryBackingStore
+}
+
+// GetId returns the id the state is saved under.
+func (p *PersistentBackingStore) GetId() string {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.id
+}
+
+// SetSaveDelay sets the delay after a change before the state is saved, the changes made meanwhile are saved together.
+// A delay of zero or less saves the state after every change.
+func (p *PersistentBackingStore) SetSaveDelay(delay time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.saveDelay = delay
+}
+
+// Flush saves the changes that were not saved yet.
+func (p *PersistentBackingStore) Flush() error {
+	p.lock.Lock()
+	pending := p.pending
+	p.stopPendingSave()
+	p.lock.Unlock()
+	if !pending {
+		return nil
+	}
+	return p.saveChanges()
+}
+
+// GetLastSaveError returns the error of the last save made after a change, nil when it succeeded.
+func (p *PersistentBackingStore) GetLastSaveError() error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.lastSaveError
+}
+
+func (p *PersistentBackingStore) checkModel(model serialization.Parsable) error {
+	backedModel, ok := model.(BackedModel)
+	if !ok || backedModel.GetBackingStore() != BackingStore(p) {
+		return errors.New("the model is not backed by this store")
+	}
+	return nil
+}
+
+// Bind saves the state of the model backed by the store and saves it again after every change.
+func (p *PersistentBackingStore) Bind(model serialization.Parsable) error {
+	if err := p.checkModel(model); err != nil {
+		return err
+	}
+	p.lock.Lock()
+	p.model = model
+	p.lock.Unlock()
+	return p.Save()
+}
+
+// Load restores the state saved under the id into the model backed by the store, which is then bound to the store under that id.
+// It returns false when no state was saved under the id.
+func (p *PersistentBackingStore) Load(id string, model serialization.Parsable) (bool, error) {
+	if err := p.checkModel(model); err != nil {
+		return false, err
+	}
+	content, err := p.storage.Load(id)
+	if errors.Is(err, ErrBackingStoreStateNotFound) {
+		p.lock.Lock()
+		p.id = id
+		p.model = model
+		p.lock.Unlock()
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	var state persistedBackingStoreState
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&state); err != nil {
+		return false, err
+	}
+
+	p.loading.Store(true)
+	defer p.loading.Store(false)
+	p.InMemoryBackingStore.Clear()
+	if state.Value != nil {
+		node := serialization.NewNativeParseNode(state.Value)
+		if err := node.SetOnBeforeAssignFieldValues(setInitializationCompleted(false)); err != nil {
+			return false, err
+		}
+		if err := node.SetOnAfterAssignFieldValues(setInitializationCompleted(true)); err != nil {
+			return false, err
+		}
+		if _, err := node.GetObjectValue(func(serialization.ParseNode) (serialization.Parsable, error) {
+			return model, nil
+		}); err != nil {
+			return false, err
+		}
+	}
+	applyPersistedChanges(p, state.Changes, make(map[*InMemoryBackingStore]bool))
+	p.InMemoryBackingStore.SetInitializationCompleted(state.InitializationCompleted)
+
+	p.lock.Lock()
+	p.id = id
+	p.model = model
+	p.lock.Unlock()
+	return true, nil
+}
+
+func setInitializationCompleted(value bool) serialization.ParsableAction {
+	return func(parsable serialization.Parsable) error {
+		if backedModel, ok := parsable.(BackedModel); ok && backedModel.GetBackingStore() != nil {
+			backedModel.GetBackingStore().SetInitializationCompleted(value)
+		}
+		return nil
+	}
+}
+
+// Save saves the state of the bound model, it returns ErrReturnOnlyChangedValues when a store of the model returns only its changed values.
+func (p *PersistentBackingStore) Save() error {
+	p.lock.RLock()
+	id := p.id
+	model := p.model
+	p.lock.RUnlock()
+	if model == nil {
+		return errors.New("no model is bound to the store")
+	}
+
+	p.saveLock.Lock()
+	defer p.saveLock.Unlock()
+	value, err := serializeAllValues(model)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(persistedBackingStoreState{
+		InitializationCompleted: p.InMemoryBackingStore.GetInitializationCompleted(),
+		Value:                   value,
+		Changes:                 collectPersistedChanges(p, make(map[*InMemoryBackingStore]bool)),
+	})
+	if err != nil {
+		return err
+	}
+	return p.storage.Save(id, content)
+}
+
+// Delete removes the saved state, the model is no longer saved after its changes until it is bound again.
+func (p *PersistentBackingStore) Delete() error {
+	p.lock.Lock()
+	id := p.id
+	p.model = nil
+	p.stopPendingSave()
+	p.lock.Unlock()
+	return p.storage.Delete(id)
+}
+
+// stopPendingSave drops the changes that were not saved yet, the caller must hold the lock.
+func (p *PersistentBackingStore) stopPendingSave() {
+	p.pending = false
+	if p.saveTimer != nil {
+		p.saveTimer.Stop()
+		p.saveTimer = nil
+	}
+}
+
+// autoSave saves the state after a change, once the save delay elapsed.
+func (p *PersistentBackingStore) autoSave() {
+	if p.loading.Load() || !p.InMemoryBackingStore.GetInitializationCompleted() {
+		return
+	}
+	p.lock.Lock()
+	if p.model == nil {
+		p.lock.Unlock()
+		return
+	}
+	p.pending = true
+	if p.saveDelay <= 0 {
+		p.lock.Unlock()
+		p.savePendingChanges()
+		return
+	}
+	if p.saveTimer == nil {
+		p.saveTimer = time.AfterFunc(p.saveDelay, p.savePendingChanges)
+	}
+	p.lock.Unlock()
+}
+
+// savePendingChanges saves the changes that were not saved yet.
+func (p *PersistentBackingStore) savePendingChanges() {
+	p.lock.Lock()
+	pending := p.pending
+	p.pending = false
+	p.saveTimer = nil
+	p.lock.Unlock()
+	if pending {
+		_ = p.saveChanges()
+	}
+}
+
+// saveChanges saves the state and records the error, the changes stay pending when the model is being serialized
+// with only its changed values and are saved on the next change or initialization, which the serialization proxies set once done.
+func (p *PersistentBackingStore) saveChanges() error {
+	err := p.Save()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if errors.Is(err, ErrReturnOnlyChangedValues) {
+		p.pending = true
+	}
+	p.lastSaveError = err
+	return err
+}
+
+func (p *PersistentBackingStore) SetInitializationCompleted(val bool) {
+	p.InMemoryBackingStore.SetInitializationCompleted(val)
+	p.autoSave()
+}
+
+func (p *PersistentBackingStore) Clear() {
+	p.InMemoryBackingStore.Clear()
+	p.autoSave()
+}
+
+func (p *PersistentBackingStore) Restore(snapshot *BackingStoreSnapshot) error {
+	if err := p.InMemoryBackingStore.Restore(snapshot); err != nil {
+		return err
+	}
+	p.autoSave()
+	return nil
+}
+
+func (p *PersistentBackingStore) Rollback() error {
+	if err := p.InMemoryBackingStore.Rollback(); err != nil {
+		return err
+	}
+	p.autoSave()
+	return nil
+}
+
+// serializeAllValues serializes all the values of the model into native values. The flags of the stores are not changed
+// as a request may be serialized concurrently, ErrReturnOnlyChangedValues is returned when a store returned only its changed values meanwhile.
+func serializeAllValues(model serialization.Parsable) (any, error) {
+	versions := make(map[*InMemoryBackingStore]uint64)
+	writer := serialization.NewNativeSerializationWriter()
+	if err := writer.SetOnBeforeSerialization(func(parsable serialization.Parsable) error {
+		backedModel, ok := parsable.(BackedModel)
+		if !ok || backedModel.GetBackingStore() == nil {
+			return nil
+		}
+		store := backedModel.GetBackingStore()
+		memoryStore, ok := asInMemoryBackingStore(store)
+		if !ok {
+			if store.GetReturnOnlyChangedValues() {
+				return ErrReturnOnlyChangedValues
+			}
+			return nil
+		}
+		version, all := memoryStore.returnsAllValues()
+		if !all {
+			return ErrReturnOnlyChangedValues
+		}
+		if _, ok := versions[memoryStore]; !ok {
+			versions[memoryStore] = version
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteObjectValue("", model); err != nil {
+		return nil, err
+	}
+	for memoryStore, version := range versions {
+		if current, all := memoryStore.returnsAllValues(); !all || current != version {
+			return nil, ErrReturnOnlyChangedValues
+		}
+	}
+	return writer.GetValue(), nil
+}
+
+// collectPersistedChanges returns the change flags of the store and of the stores of its nested models.
+func collectPersistedChanges(store BackingStore, visited map[*InMemoryBackingStore]bool) *persistedChanges {
+	memoryStore, ok := asInMemoryBackingStore(store)
+	if !ok || visited[memoryStore] {
+		return nil
+	}
+	visited[memoryStore] = true
+
+	changed, values := memoryStore.changes()
+	sort.Strings(changed)
+	result := &persistedChanges{
+		Changed: changed,
+		Nested:  make(map[string]*persistedChanges),
+		Items:   make(map[string][]*persistedChanges),
+	}
+	for k, v := range values {
+		if model, ok := v.(BackedModel); ok {
+			if child := backingStoreOf(model); child != nil {
+				if nested := collectPersistedChanges(child, visited); !nested.isEmpty() {
+					result.Nested[k] = nested
+				}
+			}
+			continue
+		}
+		items := reflect.ValueOf(v)
+		if items.Kind() != reflect.Slice {
+			continue
+		}
+		itemChanges := make([]*persistedChanges, items.Len())
+		empty := true
+		for index := 0; index < items.Len(); index++ {
+			if model, ok := items.Index(index).Interface().(BackedModel); ok {
+				if child := backingStoreOf(model); child != nil {
+					itemChanges[index] = collectPersistedChanges(child, visited)
+					empty = empty && itemChanges[index].isEmpty()
+				}
+			}
+		}
+		if !empty {
+			result.Items[k] = itemChanges
+		}
+	}
+	return result
+}
+
+// applyPersistedChanges restores the change flags of the store and of the stores of its nested models.
+func applyPersistedChanges(store BackingStore, changes *persistedChanges, visited map[*InMemoryBackingStore]bool) {
+	memoryStore, ok := asInMemoryBackingStore(store)
+	if !ok || changes == nil || visited[memoryStore] {
+		return
+	}
+	visited[memoryStore] = true
+
+	memoryStore.markChanged(changes.Changed)
+	_, values := memoryStore.changes()
+	for k, nested := range changes.Nested {
+		if model, ok := values[k].(BackedModel); ok {
+			if child := backingStoreOf(model); child != nil {
+				applyPersistedChanges(child, nested, visited)
+			}
+		}
+	}
+	for k, itemChanges := range changes.Items {
+		items := reflect.ValueOf(values[k])
+		if items.Kind() != reflect.Slice {
+			continue
+		}
+		for index := 0; index < items.Len() && index < len(itemChanges); index++ {
+			if model, ok := items.Index(index).Interface().(BackedModel); ok {
+				if child := backingStoreOf(model); child != nil {
+					applyPersistedChanges(child, itemChanges[index], visited)
+				}
+			}
+		}
+	}
+}
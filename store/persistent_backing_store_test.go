@@ -0,0 +1,188 @@
+package store
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPersistentTestEntity(storage BackingStoreStorage, id string) *testEntity {
+	return &testEntity{
+		backingStore: NewPersistentBackingStore(storage, id),
+	}
+}
+
+func TestPersistentBackingStoreReloadsChanges(t *testing.T) {
+	storage, err := NewDirectoryBackingStoreStorage(t.TempDir())
+	assert.Nil(t, err)
+
+	entity := newPersistentTestEntity(storage, "entity")
+	store := entity.GetBackingStore().(*PersistentBackingStore)
+	found, err := store.Load("entity", entity)
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	node, err := NewBackingStoreParseNodeFactory(&nativeJsonParseNodeFactory{}).GetRootParseNode("application/json", []byte(`{"id":"1","name":"parent","phoneNumbers":["+1234"],"manager":{"id":"2","name":"boss"},"items":[{"id":"3"},{"id":"4"}]}`))
+	assert.Nil(t, err)
+	_, err = node.GetObjectValue(func(serialization.ParseNode) (serialization.Parsable, error) { return entity, nil })
+	assert.Nil(t, err)
+
+	entity.SetName(nil)
+	managerName := "new boss"
+	entity.GetManager().SetName(&managerName)
+	itemName := "item"
+	entity.GetItems()[1].SetName(&itemName)
+	assert.Nil(t, store.Flush())
+	assert.Nil(t, store.GetLastSaveError())
+	expected, err := GetMergePatch(entity)
+	assert.Nil(t, err)
+
+	// simulates a restart of the application
+	reloaded := newPersistentTestEntity(storage, "other")
+	found, err = reloaded.GetBackingStore().(*PersistentBackingStore).Load("entity", reloaded)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.True(t, reloaded.GetBackingStore().GetInitializationCompleted())
+	assert.Equal(t, "entity", reloaded.GetBackingStore().(*PersistentBackingStore).GetId())
+	assert.Equal(t, []string{"name"}, reloaded.GetBackingStore().EnumerateKeysForValuesChangedToNil())
+	assert.Equal(t, "1", *reloaded.GetId())
+	assert.Equal(t, []string{"+1234"}, reloaded.GetPhoneNumbers())
+
+	patch, err := GetMergePatch(reloaded)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, patch)
+	assert.Equal(t, map[string]any{
+		"name":    nil,
+		"manager": map[string]any{"name": "new boss"},
+		"items":   []any{map[string]any{"id": "3"}, map[string]any{"id": "4", "name": "item"}},
+	}, patch)
+
+	// changes made after the reload are saved as well
+	reloaded.SetPhoneNumbers([]string{"+2345"})
+	assert.Nil(t, reloaded.GetBackingStore().(*PersistentBackingStore).Flush())
+	again := newPersistentTestEntity(storage, "again")
+	found, err = again.GetBackingStore().(*PersistentBackingStore).Load("entity", again)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	again.GetBackingStore().SetReturnOnlyChangedValues(true)
+	assert.Equal(t, []string{"items", "manager", "name", "phoneNumbers"}, keysOf(again.GetBackingStore().Enumerate()))
+
+	assert.Nil(t, again.GetBackingStore().(*PersistentBackingStore).Delete())
+	_, err = storage.Load("entity")
+	assert.Equal(t, ErrBackingStoreStateNotFound, err)
+}
+
+func TestPersistentBackingStoreRequiresItsModel(t *testing.T) {
+	storage, err := NewDirectoryBackingStoreStorage(t.TempDir())
+	assert.Nil(t, err)
+	store := NewPersistentBackingStore(storage, "entity")
+
+	assert.NotNil(t, store.Bind(NewTestEntity()))
+	_, err = store.Load("entity", NewTestEntity())
+	assert.NotNil(t, err)
+	assert.NotNil(t, store.Save())
+}
+
+func TestPersistentBackingStoreFactory(t *testing.T) {
+	storage, err := NewDirectoryBackingStoreStorage(t.TempDir())
+	assert.Nil(t, err)
+	factory := NewPersistentBackingStoreFactory(storage)
+
+	first := factory().(*PersistentBackingStore)
+	second := factory().(*PersistentBackingStore)
+	assert.NotEqual(t, first.GetId(), second.GetId())
+
+	entity := &testEntity{backingStore: first}
+	id := "1"
+	entity.SetId(&id)
+	assert.Nil(t, first.Bind(entity))
+	content, err := storage.Load(first.GetId())
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `"changed":["id"]`)
+}
+
+// countingStorage is a BackingStoreStorage keeping the states in memory and counting the saves.
+type countingStorage struct {
+	lock   sync.Mutex
+	states map[string][]byte
+	saves  int
+}
+
+func (c *countingStorage) Load(id string) ([]byte, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if state, ok := c.states[id]; ok {
+		return state, nil
+	}
+	return nil, ErrBackingStoreStateNotFound
+}
+
+func (c *countingStorage) Save(id string, state []byte) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.states[id] = state
+	c.saves++
+	return nil
+}
+
+func (c *countingStorage) Delete(id string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.states, id)
+	return nil
+}
+
+func (c *countingStorage) getSaves() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.saves
+}
+
+func TestPersistentBackingStoreSavesTheChangesTogether(t *testing.T) {
+	storage := &countingStorage{states: make(map[string][]byte)}
+	entity := newPersistentTestEntity(storage, "entity")
+	store := entity.GetBackingStore().(*PersistentBackingStore)
+	store.SetSaveDelay(time.Hour)
+	assert.Nil(t, store.Bind(entity))
+	assert.Equal(t, 1, storage.getSaves())
+
+	for _, name := range []string{"a", "b", "c"} {
+		entity.SetName(&name)
+	}
+	assert.Equal(t, 1, storage.getSaves())
+	assert.Nil(t, store.Flush())
+	assert.Equal(t, 2, storage.getSaves())
+	assert.Nil(t, store.Flush())
+	assert.Equal(t, 2, storage.getSaves())
+
+	store.SetSaveDelay(time.Millisecond)
+	name := "d"
+	entity.SetName(&name)
+	assert.Eventually(t, func() bool { return storage.getSaves() == 3 }, time.Second, time.Millisecond)
+}
+
+func TestPersistentBackingStoreDoesNotChangeTheFlagsOfTheStores(t *testing.T) {
+	storage := &countingStorage{states: make(map[string][]byte)}
+	entity := newPersistentTestEntity(storage, "entity")
+	store := entity.GetBackingStore().(*PersistentBackingStore)
+	store.SetSaveDelay(0)
+	assert.Nil(t, store.Bind(entity))
+
+	// as while the model is serialized for a request
+	store.SetReturnOnlyChangedValues(true)
+	name := "changed"
+	entity.SetName(&name)
+	assert.Equal(t, ErrReturnOnlyChangedValues, store.GetLastSaveError())
+	assert.True(t, store.GetReturnOnlyChangedValues())
+	assert.Equal(t, 1, storage.getSaves())
+
+	// the pending changes are saved once the serialization completed
+	store.SetReturnOnlyChangedValues(false)
+	store.SetInitializationCompleted(true)
+	assert.Nil(t, store.GetLastSaveError())
+	assert.Equal(t, 2, storage.getSaves())
+}
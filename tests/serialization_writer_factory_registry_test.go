@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/microsoft/kiota-abstractions-go/serialization"
@@ -20,3 +21,18 @@ func TestItGetsVendorSpecificSerializationWriter(t *testing.T) {
 func TestSerializationWriterFactoryRegistryHonoursInterface(t *testing.T) {
 	assert.Implements(t, (*serialization.SerializationWriterFactory)(nil), serialization.DefaultSerializationWriterFactoryInstance)
 }
+
+func TestSerializationWriterFactoryRegistryHonoursStreamInterface(t *testing.T) {
+	assert.Implements(t, (*serialization.StreamSerializationWriterFactory)(nil), serialization.DefaultSerializationWriterFactoryInstance)
+}
+
+func TestItGetsABufferedStreamSerializationWriter(t *testing.T) {
+	serialization.DefaultSerializationWriterFactoryInstance.ContentTypeAssociatedFactories["application/json"] = &internal.MockSerializerFactory{SerializedValue: "{}"}
+	var buffer bytes.Buffer
+	serializationWriter, err := serialization.DefaultSerializationWriterFactoryInstance.GetStreamSerializationWriter("application/json", &buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, buffer.Len())
+	assert.Nil(t, serializationWriter.Close())
+	assert.Equal(t, "{}", buffer.String())
+	serialization.DefaultSerializationWriterFactoryInstance.ContentTypeAssociatedFactories = make(map[string]serialization.SerializationWriterFactory)
+}
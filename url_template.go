@@ -0,0 +1,414 @@
+package abstractions
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	u "net/url"
+)
+
+// ErrUrlTemplateMismatch is returned when a URL does not match a URL template.
+var ErrUrlTemplateMismatch = errors.New("the url does not match the url template")
+
+// UrlTemplateValidationError is returned when the parameters of a request do not match the variables of its URL template.
+type UrlTemplateValidationError struct {
+	// Missing holds the names of the required variables without a value.
+	Missing []string
+	// Unknown holds the names of the parameters which are not variables of the URL template.
+	Unknown []string
+}
+
+func (e *UrlTemplateValidationError) Error() string {
+	problems := make([]string, 0, 2)
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unknown) > 0 {
+		problems = append(problems, "unknown "+strings.Join(e.Unknown, ", "))
+	}
+	return "the parameters do not match the url template: " + strings.Join(problems, "; ")
+}
+
+// UrlTemplateMatch holds the parameters read from a URL matched against a URL template.
+// Values are strings, lists are returned as []any of strings.
+type UrlTemplateMatch struct {
+	// PathParametersAny holds the values of the variables of the path, keyed by their name in the template.
+	PathParametersAny map[string]any
+	// QueryParametersAny holds the values of the variables of the query, keyed by their name in the template.
+	QueryParametersAny map[string]any
+}
+
+// urlTemplateOperator describes how the variables of an RFC 6570 expression are expanded.
+type urlTemplateOperator struct {
+	// first is written before the first defined variable.
+	first string
+	// separator is written between the defined variables.
+	separator string
+	// named writes the variables as name=value pairs.
+	named bool
+	// reserved keeps the reserved characters of the values.
+	reserved bool
+	// query expands the variables in the query of the URL.
+	query bool
+}
+
+var urlTemplateOperators = map[string]urlTemplateOperator{
+	"":  {first: "", separator: ","},
+	"+": {first: "", separator: ",", reserved: true},
+	"#": {first: "#", separator: ",", reserved: true},
+	".": {first: ".", separator: "."},
+	"/": {first: "/", separator: "/"},
+	";": {first: ";", separator: ";", named: true},
+	"?": {first: "?", separator: "&", named: true, query: true},
+	"&": {first: "&", separator: "&", named: true, query: true},
+}
+
+var urlTemplateVariableName = regexp.MustCompile(`^(?:[A-Za-z0-9_.]|%[0-9A-Fa-f]{2})+$`)
+
+// UrlTemplateVariable describes a variable of an RFC 6570 URL template.
+type UrlTemplateVariable struct {
+	// Name is the name of the variable as written in the template, e.g. user%2Did.
+	Name string
+	// Operator is the operator of the expression holding the variable, empty for simple string expansions.
+	Operator string
+	// Explode is whether the values of lists and maps are expanded as separate items.
+	Explode bool
+	// Prefix is the maximum length of the expanded value, 0 when unlimited.
+	Prefix int
+	// Required is whether the variable is a path segment which cannot be left undefined,
+	// simple and reserved expansions leave an empty segment while the other operators are omitted when undefined.
+	Required bool
+}
+
+// urlTemplateExpression is an RFC 6570 expression, e.g. {?%24select,%24top}.
+type urlTemplateExpression struct {
+	operator  string
+	variables []UrlTemplateVariable
+}
+
+// urlTemplatePart is either a literal or an expression of a URL template.
+type urlTemplatePart struct {
+	literal    string
+	expression *urlTemplateExpression
+}
+
+// parseUrlTemplate splits an RFC 6570 URL template into its literals and expressions.
+func parseUrlTemplate(urlTemplate string) ([]urlTemplatePart, error) {
+	parts := make([]urlTemplatePart, 0)
+	remaining := urlTemplate
+	for remaining != "" {
+		start := strings.IndexAny(remaining, "{}")
+		if start < 0 {
+			parts = append(parts, urlTemplatePart{literal: remaining})
+			break
+		}
+		if remaining[start] == '}' {
+			return nil, fmt.Errorf("unexpected } at position %d of the url template", len(urlTemplate)-len(remaining)+start)
+		}
+		if start > 0 {
+			parts = append(parts, urlTemplatePart{literal: remaining[:start]})
+		}
+		end := strings.IndexAny(remaining[start+1:], "{}")
+		if end < 0 || remaining[start+1+end] != '}' {
+			return nil, fmt.Errorf("unclosed expression at position %d of the url template", len(urlTemplate)-len(remaining)+start)
+		}
+		expression, err := parseUrlTemplateExpression(remaining[start+1 : start+1+end])
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, urlTemplatePart{expression: expression})
+		remaining = remaining[start+end+2:]
+	}
+	return parts, nil
+}
+
+func parseUrlTemplateExpression(text string) (*urlTemplateExpression, error) {
+	expression := &urlTemplateExpression{}
+	if text != "" {
+		if _, ok := urlTemplateOperators[text[:1]]; ok {
+			expression.operator = text[:1]
+			text = text[1:]
+		} else if strings.ContainsAny(text[:1], "=,!@|") {
+			return nil, fmt.Errorf("the operator %q is reserved and not supported", text[:1])
+		}
+	}
+	if text == "" {
+		return nil, errors.New("an expression of the url template has no variable")
+	}
+	for _, spec := range strings.Split(text, ",") {
+		variable := UrlTemplateVariable{
+			Name:     spec,
+			Operator: expression.operator,
+			Required: expression.operator == "" || expression.operator == "+",
+		}
+		if strings.HasSuffix(spec, "*") {
+			variable.Name = strings.TrimSuffix(spec, "*")
+			variable.Explode = true
+		} else if name, length, ok := strings.Cut(spec, ":"); ok {
+			prefix, err := strconv.Atoi(length)
+			if err != nil || prefix <= 0 || prefix >= 10000 || strings.HasPrefix(length, "+") {
+				return nil, fmt.Errorf("the prefix of the variable %q must be a number between 1 and 9999", name)
+			}
+			variable.Name = name
+			variable.Prefix = prefix
+		}
+		if !urlTemplateVariableName.MatchString(variable.Name) {
+			return nil, fmt.Errorf("%q is not a valid variable name", variable.Name)
+		}
+		expression.variables = append(expression.variables, variable)
+	}
+	return expression, nil
+}
+
+// GetUrlTemplateVariables returns the variables of the RFC 6570 URL template in the order they appear.
+func GetUrlTemplateVariables(urlTemplate string) ([]UrlTemplateVariable, error) {
+	parts, err := parseUrlTemplate(urlTemplate)
+	if err != nil {
+		return nil, err
+	}
+	variables := make([]UrlTemplateVariable, 0)
+	for _, part := range parts {
+		if part.expression != nil {
+			variables = append(variables, part.expression.variables...)
+		}
+	}
+	return variables, nil
+}
+
+// validateUrlTemplateParameters returns a *UrlTemplateValidationError when required variables of the URL template have no value
+// or parameters are not variables of the URL template.
+func validateUrlTemplateParameters(urlTemplate string, parameters map[string]any) error {
+	variables, err := GetUrlTemplateVariables(urlTemplate)
+	if err != nil {
+		return err
+	}
+	result := &UrlTemplateValidationError{}
+	names := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		if names[variable.Name] {
+			continue
+		}
+		names[variable.Name] = true
+		if variable.Required && isEmptyParameter(parameters[variable.Name]) {
+			result.Missing = append(result.Missing, variable.Name)
+		}
+	}
+	for name := range parameters {
+		if !names[name] {
+			result.Unknown = append(result.Unknown, name)
+		}
+	}
+	if len(result.Missing) == 0 && len(result.Unknown) == 0 {
+		return nil
+	}
+	sort.Strings(result.Unknown)
+	return result
+}
+
+// isEmptyParameter returns whether the value expands to nothing: nil, an empty string, an empty collection
+// or a value of a type the URL template expansion does not support.
+func isEmptyParameter(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case float32, float64, int, int8, int16, int32, int64, bool:
+		return false
+	case []string, []float32, []float64, []int, []int8, []int16, []int32, []int64, []bool, []any,
+		map[string]string, map[string]float32, map[string]float64, map[string]int, map[string]int8, map[string]int16, map[string]int32, map[string]int64, map[string]bool, map[string]any:
+		return reflect.ValueOf(v).Len() == 0
+	default:
+		return true
+	}
+}
+
+// pattern returns the regular expression matching the expansion of an expression of the path.
+func (e *urlTemplateExpression) pattern() string {
+	switch e.operator {
+	case "+":
+		return `([^?#]*?)`
+	case "#":
+		return `(#.*)?`
+	case ".":
+		return `((?:\.[^/?#;.]*)*?)`
+	case "/":
+		return `((?:/[^/?#;]*?)*?)`
+	case ";":
+		return `((?:;[^/?#;]*)*?)`
+	default:
+		return `([^/?#;]*?)`
+	}
+}
+
+// MatchUrlTemplate matches the URL against the RFC 6570 URL template and returns the values of its variables,
+// ErrUrlTemplateMismatch when the URL does not match or holds query parameters the template does not define.
+func MatchUrlTemplate(urlTemplate string, url u.URL) (*UrlTemplateMatch, error) {
+	parts, err := parseUrlTemplate(urlTemplate)
+	if err != nil {
+		return nil, err
+	}
+	pattern := strings.Builder{}
+	pattern.WriteString("^")
+	expressions := make([]*urlTemplateExpression, 0)
+	queryExpressions := make([]*urlTemplateExpression, 0)
+	for _, part := range parts {
+		switch {
+		case part.expression == nil:
+			pattern.WriteString(regexp.QuoteMeta(part.literal))
+		case urlTemplateOperators[part.expression.operator].query:
+			queryExpressions = append(queryExpressions, part.expression)
+		default:
+			pattern.WriteString(part.expression.pattern())
+			expressions = append(expressions, part.expression)
+		}
+	}
+	pattern.WriteString("$")
+	matcher, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.RawQuery
+	url.RawQuery = ""
+	url.ForceQuery = false
+	groups := matcher.FindStringSubmatch(url.String())
+	if groups == nil {
+		return nil, ErrUrlTemplateMismatch
+	}
+
+	result := &UrlTemplateMatch{
+		PathParametersAny:  make(map[string]any),
+		QueryParametersAny: make(map[string]any),
+	}
+	for index, expression := range expressions {
+		if err := expression.read(groups[index+1], result.PathParametersAny); err != nil {
+			return nil, err
+		}
+	}
+	if err := readUrlTemplateQuery(queryExpressions, query, result.QueryParametersAny); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// read reads the values of the variables from the expansion of an expression of the path.
+func (e *urlTemplateExpression) read(expansion string, values map[string]any) error {
+	operator := urlTemplateOperators[e.operator]
+	expansion = strings.TrimPrefix(expansion, operator.first)
+	if expansion == "" {
+		return nil
+	}
+	items := strings.Split(expansion, operator.separator)
+	if operator.named {
+		for _, item := range items {
+			name, value, _ := strings.Cut(item, "=")
+			for _, variable := range e.variables {
+				if sameVariableName(variable.Name, name) {
+					if err := addUrlTemplateValue(values, variable, value, operator); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+	for index, variable := range e.variables {
+		if index >= len(items) {
+			break
+		}
+		if index == len(e.variables)-1 && len(items) > len(e.variables) {
+			// the last variable holds the remaining items of a list
+			for _, item := range items[index:] {
+				if err := addUrlTemplateValue(values, variable, item, operator); err != nil {
+					return err
+				}
+			}
+			break
+		}
+		if err := addUrlTemplateValue(values, variable, items[index], operator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readUrlTemplateQuery reads the values of the variables of the query expressions from the raw query of the URL.
+func readUrlTemplateQuery(expressions []*urlTemplateExpression, rawQuery string, values map[string]any) error {
+	for _, item := range strings.Split(rawQuery, "&") {
+		if item == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(item, "=")
+		matched := false
+		for _, expression := range expressions {
+			for _, variable := range expression.variables {
+				if sameVariableName(variable.Name, name) {
+					matched = true
+					if err := addUrlTemplateValue(values, variable, value, urlTemplateOperators[expression.operator]); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if !matched {
+			return fmt.Errorf("the query parameter %q is not defined by the url template: %w", name, ErrUrlTemplateMismatch)
+		}
+	}
+	return nil
+}
+
+// sameVariableName returns whether the name read from a URL designates the variable, names are compared once decoded.
+func sameVariableName(variable string, name string) bool {
+	if variable == name {
+		return true
+	}
+	decodedVariable, err := u.PathUnescape(variable)
+	if err != nil {
+		return false
+	}
+	decodedName, err := u.QueryUnescape(name)
+	return err == nil && decodedVariable == decodedName
+}
+
+// addUrlTemplateValue decodes the value and adds it to the values of the variable, repeated values and comma separated values make lists.
+func addUrlTemplateValue(values map[string]any, variable UrlTemplateVariable, value string, operator urlTemplateOperator) error {
+	var items []string
+	if !operator.reserved && strings.Contains(value, ",") {
+		items = strings.Split(value, ",")
+	} else {
+		items = []string{value}
+	}
+	decoded := make([]any, 0, len(items))
+	for _, item := range items {
+		if !operator.reserved {
+			unescape := u.PathUnescape
+			if operator.query {
+				unescape = u.QueryUnescape
+			}
+			var err error
+			if item, err = unescape(item); err != nil {
+				return fmt.Errorf("could not decode the value of %q: %w", variable.Name, err)
+			}
+		}
+		decoded = append(decoded, item)
+	}
+
+	existing, exists := values[variable.Name]
+	switch {
+	case exists:
+		if list, ok := existing.([]any); ok {
+			values[variable.Name] = append(list, decoded...)
+		} else {
+			values[variable.Name] = append([]any{existing}, decoded...)
+		}
+	case len(decoded) > 1:
+		values[variable.Name] = decoded
+	default:
+		values[variable.Name] = decoded[0]
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package abstractions
+
+import (
+	"errors"
+	u "net/url"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+const usersMessagesTemplate = "{+baseurl}/users/{user%2Did}/messages{?%24count,%24filter,%24select*,%24top}"
+
+func mustParseUrl(t *testing.T, rawUrl string) u.URL {
+	url, err := u.Parse(rawUrl)
+	assert.Nil(t, err)
+	return *url
+}
+
+func TestItMatchesUrlTemplates(t *testing.T) {
+	match, err := MatchUrlTemplate(usersMessagesTemplate, mustParseUrl(t, "https://graph.microsoft.com/v1.0/users/jane%40contoso.com/messages?%24filter=subject%20eq%20%27hi%27&%24select=id,subject&$top=10"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{
+		"baseurl":   "https://graph.microsoft.com/v1.0",
+		"user%2Did": "jane@contoso.com",
+	}, match.PathParametersAny)
+	assert.Equal(t, map[string]any{
+		"%24filter": "subject eq 'hi'",
+		"%24select": []any{"id", "subject"},
+		"%24top":    "10",
+	}, match.QueryParametersAny)
+}
+
+func TestItMatchesExplodedQueryParameters(t *testing.T) {
+	match, err := MatchUrlTemplate(usersMessagesTemplate, mustParseUrl(t, "https://graph.microsoft.com/v1.0/users/1/messages?%24select=id&%24select=subject"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"%24select": []any{"id", "subject"}}, match.QueryParametersAny)
+}
+
+func TestItRejectsUrlsNotMatchingTheTemplate(t *testing.T) {
+	_, err := MatchUrlTemplate(usersMessagesTemplate, mustParseUrl(t, "https://graph.microsoft.com/v1.0/users/1/events"))
+	assert.Equal(t, ErrUrlTemplateMismatch, err)
+
+	_, err = MatchUrlTemplate(usersMessagesTemplate, mustParseUrl(t, "https://graph.microsoft.com/v1.0/users/1/messages?%24skiptoken=abc"))
+	assert.True(t, errors.Is(err, ErrUrlTemplateMismatch))
+	assert.Contains(t, err.Error(), "%24skiptoken")
+}
+
+func TestItMatchesPathOperators(t *testing.T) {
+	match, err := MatchUrlTemplate("https://example.com/repos{/owner,repo}/files{/path*}{.format}{;version}{#section}", mustParseUrl(t, "https://example.com/repos/microsoft/kiota/files/src/main.json;version=2#top"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{
+		"owner":   "microsoft",
+		"repo":    "kiota",
+		"path":    []any{"src", "main"},
+		"format":  "json",
+		"version": "2",
+		"section": "top",
+	}, match.PathParametersAny)
+
+	match, err = MatchUrlTemplate("https://example.com/items/{ids}", mustParseUrl(t, "https://example.com/items/1,2%2C3"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"ids": []any{"1", "2,3"}}, match.PathParametersAny)
+}
+
+func TestItValidatesUrlTemplates(t *testing.T) {
+	for _, template := range []string{"{+baseurl", "{+baseurl}}", "{}", "{=value}", "{value:0}", "{va lue}"} {
+		_, err := MatchUrlTemplate(template, mustParseUrl(t, "https://example.com"))
+		assert.NotNil(t, err, template)
+		assert.False(t, errors.Is(err, ErrUrlTemplateMismatch), template)
+	}
+}
+
+func TestItSetsParametersFromUri(t *testing.T) {
+	requestInformation := NewRequestInformationWithMethodAndUrlTemplateAndPathParameters(GET, usersMessagesTemplate, map[string]string{"baseurl": "https://localhost"})
+	rawUrl := "https://graph.microsoft.com/v1.0/users/jane%40contoso.com/messages?%24count=true&%24select=id&%24select=subject&%24top=10"
+	assert.Nil(t, requestInformation.SetParametersFromUri(mustParseUrl(t, rawUrl)))
+
+	assert.Equal(t, "https://graph.microsoft.com/v1.0", requestInformation.PathParameters["baseurl"])
+	assert.Equal(t, "10", requestInformation.QueryParametersAny["%24top"])
+	uri, err := requestInformation.GetUri()
+	assert.Nil(t, err)
+	assert.Equal(t, rawUrl, uri.String())
+
+	assert.NotNil(t, requestInformation.SetParametersFromUri(mustParseUrl(t, "https://graph.microsoft.com/v1.0/groups")))
+	assert.Equal(t, "https://graph.microsoft.com/v1.0", requestInformation.PathParameters["baseurl"])
+}
+
+func TestItListsTheVariablesOfUrlTemplates(t *testing.T) {
+	variables, err := GetUrlTemplateVariables("{+baseurl}/users/{user%2Did}/files{/path*}{?%24select,%24top:3}")
+	assert.Nil(t, err)
+	assert.Equal(t, []UrlTemplateVariable{
+		{Name: "baseurl", Operator: "+", Required: true},
+		{Name: "user%2Did", Operator: "", Required: true},
+		{Name: "path", Operator: "/", Explode: true},
+		{Name: "%24select", Operator: "?"},
+		{Name: "%24top", Operator: "?", Prefix: 3},
+	}, variables)
+
+	_, err = GetUrlTemplateVariables("{+baseurl")
+	assert.NotNil(t, err)
+}
+
+func TestItValidatesTheParametersOfRequests(t *testing.T) {
+	requestInformation := NewRequestInformationWithMethodAndUrlTemplateAndPathParameters(GET, usersMessagesTemplate, map[string]string{
+		"baseurl":   "https://graph.microsoft.com/v1.0",
+		"user%2Did": "",
+	})
+	requestInformation.QueryParametersAny["%24top"] = 10
+	requestInformation.QueryParametersAny["Filter"] = "value"
+	requestInformation.PathParametersAny["id"] = "1"
+
+	err := requestInformation.ValidateUrlTemplate()
+	var validationError *UrlTemplateValidationError
+	assert.True(t, errors.As(err, &validationError))
+	assert.Equal(t, []string{"user%2Did"}, validationError.Missing)
+	assert.Equal(t, []string{"Filter", "id"}, validationError.Unknown)
+	assert.Equal(t, "the parameters do not match the url template: missing user%2Did; unknown Filter, id", err.Error())
+
+	// not strict by default
+	_, err = requestInformation.GetUri()
+	assert.Nil(t, err)
+	requestInformation.StrictUrlTemplate = true
+	_, err = requestInformation.GetUri()
+	assert.True(t, errors.As(err, &validationError))
+
+	delete(requestInformation.QueryParametersAny, "Filter")
+	delete(requestInformation.PathParametersAny, "id")
+	// pointers are not expanded
+	userId := "jane"
+	requestInformation.PathParametersAny["user%2Did"] = &userId
+	err = requestInformation.ValidateUrlTemplate()
+	assert.True(t, errors.As(err, &validationError))
+	assert.Equal(t, []string{"user%2Did"}, validationError.Missing)
+	requestInformation.PathParametersAny["user%2Did"] = userId
+	assert.Nil(t, requestInformation.ValidateUrlTemplate())
+	uri, err := requestInformation.GetUri()
+	assert.Nil(t, err)
+	assert.Equal(t, "https://graph.microsoft.com/v1.0/users/jane/messages?%24top=10", uri.String())
+
+	requestInformation.SetUri(mustParseUrl(t, "https://graph.microsoft.com/v1.0/me"))
+	assert.Nil(t, requestInformation.ValidateUrlTemplate())
+}
+
+func TestItUsesTheDefaultStrictness(t *testing.T) {
+	DefaultStrictUrlTemplate = true
+	defer func() {
+		DefaultStrictUrlTemplate = false
+	}()
+	requestInformation := NewRequestInformation()
+	requestInformation.UrlTemplate = "{+baseurl}/users/{user%2Did}"
+	requestInformation.PathParameters["baseurl"] = "https://graph.microsoft.com/v1.0"
+	assert.True(t, requestInformation.StrictUrlTemplate)
+	_, err := requestInformation.GetUri()
+	assert.NotNil(t, err)
+}